@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -30,25 +31,204 @@ type ApplicationHelmSpec struct {
 	Atomic           bool     `json:"atomic,omitempty"`
 	IncludeCRDs      bool     `json:"includeCRDs,omitempty"`
 	ValuesFiles      []string `json:"valuesFiles,omitempty"`
+	// SelfHeal upgrades the release when objects of the release manifest drifted from the live ones
+	SelfHeal bool `json:"selfHeal,omitempty"`
+	// ValuesFrom are merged over the values files in their order
+	ValuesFrom []ApplicationHelmValuesReference `json:"valuesFrom,omitempty"`
+	// Values are merged over the values files and valuesFrom
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *runtime.RawExtension `json:"values,omitempty"`
+	// Parameters are applied over all values like --set flags
+	Parameters []ApplicationHelmParameter `json:"parameters,omitempty"`
+	// PostRender changes the rendered manifest before install, upgrade, render and diff
+	PostRender ApplicationHelmPostRender `json:"postRender,omitempty"`
+}
+
+// ApplicationHelmPostRender is kustomization or plugin binary post-render, they are exclusive
+type ApplicationHelmPostRender struct {
+	// Kustomize is path of the kustomization directory relative to the chart path, the rendered manifest is added to its resources as helm-output.yaml
+	Kustomize string `json:"kustomize,omitempty"`
+	// Plugin is name of the binary in the server plugin directory, it reads the rendered manifest from stdin and writes yaml manifests to stdout
+	Plugin string   `json:"plugin,omitempty"`
+	Args   []string `json:"args,omitempty"`
+}
+
+// ApplicationHelmParameter overrides the value at the path of the name
+type ApplicationHelmParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Type is empty for --set, string for --set-string, json for --set-json and file for --set-file with path relative to the chart path
+	// +kubebuilder:validation:Enum="";string;json;file
+	Type string `json:"type,omitempty"`
+}
+
+// ApplicationHelmValuesReference references values document in the key of the Secret or ConfigMap of the application namespace
+type ApplicationHelmValuesReference struct {
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Key is values.yaml when not set
+	Key string `json:"key,omitempty"`
+	// Optional reference is skipped when the object or the key does not exist
+	Optional bool `json:"optional,omitempty"`
+}
+
+type ApplicationRawSpec struct {
+	// RecurseDepth limits directory levels to search manifests in, 1 is only sparse path itself, 0 is unlimited
+	// +kubebuilder:validation:Minimum=0
+	RecurseDepth int32 `json:"recurseDepth,omitempty"`
+	// AllowedNamespaces besides the application namespace, "*" allows any namespace
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedClusterResources are cluster-scoped kinds as "Kind" or "group/Kind", "*" allows any kind
+	AllowedClusterResources []string `json:"allowedClusterResources,omitempty"`
+	// ForceConflicts takes ownership of fields managed by other field managers on server-side apply
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+	// SelfHeal re-applies objects drifted from the git state of the delivered revision
+	SelfHeal bool `json:"selfHeal,omitempty"`
+	// WaitHealthy fails delivery unless all resources become healthy within HealthTimeoutSeconds
+	WaitHealthy bool `json:"waitHealthy,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	HealthTimeoutSeconds int32 `json:"healthTimeoutSeconds,omitempty"`
+	// Prune deletes objects removed from the sources, confirm holds them until the dummy.cd/confirm-prune annotation is set
+	// +kubebuilder:validation:Enum=enabled;disabled;confirm
+	Prune string `json:"prune,omitempty"`
+	// PruneLimitPercent holds prune for confirmation when more percent of the application objects would be deleted, 0 is unlimited
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	PruneLimitPercent int32 `json:"pruneLimitPercent,omitempty"`
+	// AllowAdoption takes over existing objects created outside of dummycd or by another application
+	AllowAdoption bool `json:"allowAdoption,omitempty"`
+	// Replace deletes and creates again objects rejected because of changed immutable fields, dummy.cd/replace annotation overrides it
+	Replace bool `json:"replace,omitempty"`
+	// ReplaceWait waits for the replaced object and its dependents to be deleted before it is created again
+	ReplaceWait bool `json:"replaceWait,omitempty"`
+	// Validate checks all objects with server-side dry-run and aborts the sync before any write if one of them fails
+	Validate bool `json:"validate,omitempty"`
+	// Atomic waits for the objects to become healthy and rolls back to the last known-good revision if the sync fails
+	Atomic bool `json:"atomic,omitempty"`
+}
+
+type ApplicationJsonnetVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// Code evaluates value as jsonnet code instead of string
+	Code bool `json:"code,omitempty"`
+}
+
+type ApplicationJsonnetSpec struct {
+	// Entrypoint relative to the sparse path, main.jsonnet by default
+	Entrypoint string `json:"entrypoint,omitempty"`
+	// JPaths are library directories relative to the repository root
+	JPaths  []string                     `json:"jpaths,omitempty"`
+	ExtVars []ApplicationJsonnetVariable `json:"extVars,omitempty"`
+	TLAs    []ApplicationJsonnetVariable `json:"tlas,omitempty"`
+}
+
+type ApplicationPluginEnv struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ApplicationPluginSpec struct {
+	// Name of the binary in the server plugin directory, it runs in the sparse path and writes yaml manifests to stdout
+	Name string                 `json:"name"`
+	Args []string               `json:"args,omitempty"`
+	Env  []ApplicationPluginEnv `json:"env,omitempty"`
+}
+
+// ApplicationIgnoreDifference excludes fields of the matched objects from diff, drift detection and self-heal
+type ApplicationIgnoreDifference struct {
+	// Group of the objects, "*" matches any group
+	Group string `json:"group,omitempty"`
+	// Kind of the objects, "*" matches any kind
+	Kind                  string   `json:"kind"`
+	Name                  string   `json:"name,omitempty"`
+	Namespace             string   `json:"namespace,omitempty"`
+	JSONPointers          []string `json:"jsonPointers,omitempty"`
+	JQPathExpressions     []string `json:"jqPathExpressions,omitempty"`
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
 }
 
 // ApplicationSpec defines the desired state of Application
 type ApplicationSpec struct {
-	URL        string              `json:"URL"`
-	Namespace  string              `json:"namespace"`
-	Reference  string              `json:"reference"`
-	SparsePath string              `json:"sparsePath"`
-	Helm       ApplicationHelmSpec `json:"helm,omitempty"`
+	URL         string                 `json:"URL"`
+	Namespace   string                 `json:"namespace"`
+	Reference   string                 `json:"reference"`
+	SparsePath  string                 `json:"sparsePath,omitempty"`
+	SparsePaths []string               `json:"sparsePaths,omitempty"`
+	Include     []string               `json:"include,omitempty"`
+	Exclude     []string               `json:"exclude,omitempty"`
+	Helm        ApplicationHelmSpec    `json:"helm,omitempty"`
+	Raw         ApplicationRawSpec     `json:"raw,omitempty"`
+	Jsonnet     ApplicationJsonnetSpec `json:"jsonnet,omitempty"`
+	Plugin      ApplicationPluginSpec  `json:"plugin,omitempty"`
+	// Provider is detected from the sparse path sources when not set
+	// +kubebuilder:validation:Enum=plugin;helm;kustomize;jsonnet;raw
+	Provider          string                        `json:"provider,omitempty"`
+	IgnoreDifferences []ApplicationIgnoreDifference `json:"ignoreDifferences,omitempty"`
+}
+
+type ApplicationResourceStatus struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	// Fields are jq-style paths of the drifted fields
+	Fields []string `json:"fields,omitempty"`
+}
+
+// ApplicationResourceResult is the outcome of the last delivery of the object
+type ApplicationResourceResult struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Operation is Created, Updated, Replaced, Unchanged or Failed
+	Operation string `json:"operation"`
+	Message   string `json:"message,omitempty"`
+}
+
+// ApplicationSyncRecord is the outcome of the sync of the revision
+type ApplicationSyncRecord struct {
+	Revision string `json:"revision"`
+	// Status is Succeeded, Failed or RolledBack
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+	// Parameters overridden at runtime when the revision was synced
+	Parameters []ApplicationHelmParameter `json:"parameters,omitempty"`
 }
 
 // ApplicationStatus defines the observed state of Application
 type ApplicationStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Sync is Synced, OutOfSync or Unknown before the first delivery
+	Sync string `json:"sync,omitempty"`
+	// Health is Healthy, Progressing, Degraded or Missing of the application resources
+	Health    string                      `json:"health,omitempty"`
+	Revision  string                      `json:"revision,omitempty"`
+	CheckedAt string                      `json:"checkedAt,omitempty"`
+	Resources []ApplicationResourceStatus `json:"resources,omitempty"`
+	// Results of the last raw delivery per object
+	Results []ApplicationResourceResult `json:"results,omitempty"`
+	// Prunes are objects removed from the sources and not deleted yet
+	Prunes []ApplicationResourceStatus `json:"prunes,omitempty"`
+	// History of the raw syncs of the revisions, the latest is the last
+	History []ApplicationSyncRecord `json:"history,omitempty"`
+	// Parameters of the helm application overridden at runtime
+	Parameters []ApplicationHelmParameter `json:"parameters,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Sync",type=string,JSONPath=`.status.sync`
+//+kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.health`
+//+kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.status.revision`
 
 // Application is the Schema for the applications API
 type Application struct {
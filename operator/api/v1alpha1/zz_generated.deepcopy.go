@@ -23,7 +23,7 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -53,6 +53,41 @@ func (in *Application) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationHelmParameter) DeepCopyInto(out *ApplicationHelmParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHelmParameter.
+func (in *ApplicationHelmParameter) DeepCopy() *ApplicationHelmParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationHelmParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationHelmPostRender) DeepCopyInto(out *ApplicationHelmPostRender) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHelmPostRender.
+func (in *ApplicationHelmPostRender) DeepCopy() *ApplicationHelmPostRender {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationHelmPostRender)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationHelmSpec) DeepCopyInto(out *ApplicationHelmSpec) {
 	*out = *in
@@ -61,6 +96,22 @@ func (in *ApplicationHelmSpec) DeepCopyInto(out *ApplicationHelmSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ApplicationHelmValuesReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ApplicationHelmParameter, len(*in))
+		copy(*out, *in)
+	}
+	in.PostRender.DeepCopyInto(&out.PostRender)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHelmSpec.
@@ -73,6 +124,96 @@ func (in *ApplicationHelmSpec) DeepCopy() *ApplicationHelmSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationHelmValuesReference) DeepCopyInto(out *ApplicationHelmValuesReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationHelmValuesReference.
+func (in *ApplicationHelmValuesReference) DeepCopy() *ApplicationHelmValuesReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationHelmValuesReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationIgnoreDifference) DeepCopyInto(out *ApplicationIgnoreDifference) {
+	*out = *in
+	if in.JSONPointers != nil {
+		in, out := &in.JSONPointers, &out.JSONPointers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JQPathExpressions != nil {
+		in, out := &in.JQPathExpressions, &out.JQPathExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationIgnoreDifference.
+func (in *ApplicationIgnoreDifference) DeepCopy() *ApplicationIgnoreDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationIgnoreDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationJsonnetSpec) DeepCopyInto(out *ApplicationJsonnetSpec) {
+	*out = *in
+	if in.JPaths != nil {
+		in, out := &in.JPaths, &out.JPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtVars != nil {
+		in, out := &in.ExtVars, &out.ExtVars
+		*out = make([]ApplicationJsonnetVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLAs != nil {
+		in, out := &in.TLAs, &out.TLAs
+		*out = make([]ApplicationJsonnetVariable, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationJsonnetSpec.
+func (in *ApplicationJsonnetSpec) DeepCopy() *ApplicationJsonnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationJsonnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationJsonnetVariable) DeepCopyInto(out *ApplicationJsonnetVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationJsonnetVariable.
+func (in *ApplicationJsonnetVariable) DeepCopy() *ApplicationJsonnetVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationJsonnetVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
 	*out = *in
@@ -105,10 +246,135 @@ func (in *ApplicationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPluginEnv) DeepCopyInto(out *ApplicationPluginEnv) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPluginEnv.
+func (in *ApplicationPluginEnv) DeepCopy() *ApplicationPluginEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPluginEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPluginSpec) DeepCopyInto(out *ApplicationPluginSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ApplicationPluginEnv, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPluginSpec.
+func (in *ApplicationPluginSpec) DeepCopy() *ApplicationPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRawSpec) DeepCopyInto(out *ApplicationRawSpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedClusterResources != nil {
+		in, out := &in.AllowedClusterResources, &out.AllowedClusterResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRawSpec.
+func (in *ApplicationRawSpec) DeepCopy() *ApplicationRawSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRawSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationResourceResult) DeepCopyInto(out *ApplicationResourceResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationResourceResult.
+func (in *ApplicationResourceResult) DeepCopy() *ApplicationResourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationResourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationResourceStatus) DeepCopyInto(out *ApplicationResourceStatus) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationResourceStatus.
+func (in *ApplicationResourceStatus) DeepCopy() *ApplicationResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
+	if in.SparsePaths != nil {
+		in, out := &in.SparsePaths, &out.SparsePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Helm.DeepCopyInto(&out.Helm)
+	in.Raw.DeepCopyInto(&out.Raw)
+	in.Jsonnet.DeepCopyInto(&out.Jsonnet)
+	in.Plugin.DeepCopyInto(&out.Plugin)
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]ApplicationIgnoreDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -131,6 +397,37 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ApplicationResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]ApplicationResourceResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prunes != nil {
+		in, out := &in.Prunes, &out.Prunes
+		*out = make([]ApplicationResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ApplicationSyncRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ApplicationHelmParameter, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
@@ -143,6 +440,26 @@ func (in *ApplicationStatus) DeepCopy() *ApplicationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSyncRecord) DeepCopyInto(out *ApplicationSyncRecord) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ApplicationHelmParameter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSyncRecord.
+func (in *ApplicationSyncRecord) DeepCopy() *ApplicationSyncRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSyncRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
@@ -21,6 +21,7 @@ import (
 	dummycdv1alpha1 "github.com/yimgzz/dummy-cd/operator/api/v1alpha1"
 	"github.com/yimgzz/dummy-cd/server/pkg/pb"
 	dummycd "github.com/yimgzz/dummy-cd/server/pkg/server"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +30,9 @@ import (
 	"time"
 )
 
+// confirmPruneAnnotation on the application confirms deletion of the objects pending prune
+const confirmPruneAnnotation = "dummy.cd/confirm-prune"
+
 // ApplicationReconciler reconciles a Application object
 type ApplicationReconciler struct {
 	client.Client
@@ -93,11 +97,14 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	log.Info("sync the application")
 
 	_, err := r.DummyClient.AddOrUpdateApplication(ctx, &pb.Application{
-		Url:        app.Spec.URL,
-		Name:       req.Name,
-		Namespace:  app.Spec.Namespace,
-		Reference:  app.Spec.Reference,
-		SparsePath: app.Spec.SparsePath,
+		Url:         app.Spec.URL,
+		Name:        req.Name,
+		Namespace:   app.Spec.Namespace,
+		Reference:   app.Spec.Reference,
+		SparsePath:  app.Spec.SparsePath,
+		SparsePaths: app.Spec.SparsePaths,
+		Include:     app.Spec.Include,
+		Exclude:     app.Spec.Exclude,
 		Helm: &pb.HelmProvider{
 			CheckValuesEqual: app.Spec.Helm.CheckValuesEqual,
 			ReInstallRelease: app.Spec.Helm.ReInstallRelease,
@@ -105,7 +112,45 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			Atomic:           app.Spec.Helm.Atomic,
 			IncludeCRDs:      app.Spec.Helm.IncludeCRDs,
 			ValuesFiles:      app.Spec.Helm.ValuesFiles,
+			SelfHeal:         app.Spec.Helm.SelfHeal,
+			ValuesFrom:       getHelmValuesFrom(app.Spec.Helm.ValuesFrom),
+			Values:           getHelmValues(app.Spec.Helm.Values),
+			Parameters:       getHelmParameters(app.Spec.Helm.Parameters),
+			PostRender: &pb.HelmPostRender{
+				Kustomize: app.Spec.Helm.PostRender.Kustomize,
+				Plugin:    app.Spec.Helm.PostRender.Plugin,
+				Args:      app.Spec.Helm.PostRender.Args,
+			},
+		},
+		Raw: &pb.RawProvider{
+			RecurseDepth:            app.Spec.Raw.RecurseDepth,
+			AllowedNamespaces:       app.Spec.Raw.AllowedNamespaces,
+			AllowedClusterResources: app.Spec.Raw.AllowedClusterResources,
+			ForceConflicts:          app.Spec.Raw.ForceConflicts,
+			SelfHeal:                app.Spec.Raw.SelfHeal,
+			WaitHealthy:             app.Spec.Raw.WaitHealthy,
+			HealthTimeoutSeconds:    app.Spec.Raw.HealthTimeoutSeconds,
+			Prune:                   app.Spec.Raw.Prune,
+			PruneLimitPercent:       app.Spec.Raw.PruneLimitPercent,
+			AllowAdoption:           app.Spec.Raw.AllowAdoption,
+			Replace:                 app.Spec.Raw.Replace,
+			ReplaceWait:             app.Spec.Raw.ReplaceWait,
+			Validate:                app.Spec.Raw.Validate,
+			Atomic:                  app.Spec.Raw.Atomic,
+		},
+		Jsonnet: &pb.JsonnetProvider{
+			Entrypoint: app.Spec.Jsonnet.Entrypoint,
+			Jpaths:     app.Spec.Jsonnet.JPaths,
+			ExtVars:    getJsonnetVariables(app.Spec.Jsonnet.ExtVars),
+			Tlas:       getJsonnetVariables(app.Spec.Jsonnet.TLAs),
 		},
+		Provider: app.Spec.Provider,
+		Plugin: &pb.PluginProvider{
+			Name: app.Spec.Plugin.Name,
+			Args: app.Spec.Plugin.Args,
+			Env:  getPluginEnv(app.Spec.Plugin.Env),
+		},
+		IgnoreDifferences: getIgnoreDifferences(app.Spec.IgnoreDifferences),
 	})
 
 	if err != nil {
@@ -116,12 +161,205 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	log.Info("the application synced")
 
+	if _, exist := app.Annotations[confirmPruneAnnotation]; exist {
+		if err := r.confirmPrune(ctx, req, app); err != nil {
+			log.Error(err, "failed to confirm prune of the application")
+		} else {
+			log.Info("prune of the application confirmed")
+		}
+	}
+
+	if err := r.updateStatus(ctx, req, app); err != nil {
+		log.Error(err, "failed to update the application status")
+	}
+
 	return ctrl.Result{RequeueAfter: time.Duration(1) * time.Minute}, nil
 }
 
+// confirmPrune confirms deletion of the objects pending prune and removes the annotation, so every prune is confirmed once
+func (r *ApplicationReconciler) confirmPrune(ctx context.Context, req ctrl.Request, app *dummycdv1alpha1.Application) error {
+	_, err := r.DummyClient.ConfirmApplicationPrune(ctx, &pb.Application{
+		Name: req.Name,
+		Url:  app.Spec.URL,
+	})
+
+	delete(app.Annotations, confirmPruneAnnotation)
+
+	if updateErr := r.Update(ctx, app); updateErr != nil {
+		return updateErr
+	}
+
+	return err
+}
+
+// updateStatus sets sync status of the last delivery and health of the application reported by the server
+func (r *ApplicationReconciler) updateStatus(ctx context.Context, req ctrl.Request, app *dummycdv1alpha1.Application) error {
+	status, err := r.DummyClient.GetApplicationStatus(ctx, &pb.Application{
+		Name: req.Name,
+		Url:  app.Spec.URL,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	health, err := r.DummyClient.GetApplicationHealth(ctx, &pb.Application{
+		Name: req.Name,
+		Url:  app.Spec.URL,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	previous := app.Status.DeepCopy()
+
+	app.Status.Sync = status.GetSync()
+	app.Status.Health = health.GetStatus()
+	app.Status.Revision = status.GetRevision().GetHash()
+	app.Status.CheckedAt = status.GetCheckedAt()
+	app.Status.Resources = nil
+
+	for _, resource := range status.GetResources() {
+		app.Status.Resources = append(app.Status.Resources, dummycdv1alpha1.ApplicationResourceStatus{
+			APIVersion: resource.GetApiVersion(),
+			Kind:       resource.GetKind(),
+			Namespace:  resource.GetNamespace(),
+			Name:       resource.GetName(),
+			Status:     resource.GetStatus(),
+			Message:    resource.GetMessage(),
+			Fields:     resource.GetFields(),
+		})
+	}
+
+	app.Status.Prunes = nil
+
+	for _, prune := range status.GetPrunes() {
+		app.Status.Prunes = append(app.Status.Prunes, dummycdv1alpha1.ApplicationResourceStatus{
+			APIVersion: prune.GetApiVersion(),
+			Kind:       prune.GetKind(),
+			Namespace:  prune.GetNamespace(),
+			Name:       prune.GetName(),
+			Status:     prune.GetStatus(),
+			Message:    prune.GetMessage(),
+		})
+	}
+
+	app.Status.Results = nil
+
+	for _, result := range status.GetResults() {
+		app.Status.Results = append(app.Status.Results, dummycdv1alpha1.ApplicationResourceResult{
+			APIVersion: result.GetApiVersion(),
+			Kind:       result.GetKind(),
+			Namespace:  result.GetNamespace(),
+			Name:       result.GetName(),
+			Operation:  result.GetOperation(),
+			Message:    result.GetMessage(),
+		})
+	}
+
+	app.Status.History = nil
+
+	for _, record := range status.GetHistory() {
+		app.Status.History = append(app.Status.History, dummycdv1alpha1.ApplicationSyncRecord{
+			Revision:   record.GetRevision(),
+			Status:     record.GetStatus(),
+			Message:    record.GetMessage(),
+			StartedAt:  record.GetStartedAt(),
+			FinishedAt: record.GetFinishedAt(),
+			Parameters: newHelmParameters(record.GetParameters()),
+		})
+	}
+
+	app.Status.Parameters = newHelmParameters(status.GetParameters())
+
+	if equality.Semantic.DeepEqual(previous, &app.Status) {
+		return nil
+	}
+
+	return r.Status().Update(ctx, app)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dummycdv1alpha1.Application{}).
 		Complete(r)
 }
+
+func getJsonnetVariables(variables []dummycdv1alpha1.ApplicationJsonnetVariable) []*pb.JsonnetVariable {
+	var out []*pb.JsonnetVariable
+
+	for _, v := range variables {
+		out = append(out, &pb.JsonnetVariable{Name: v.Name, Value: v.Value, Code: v.Code})
+	}
+
+	return out
+}
+
+func getPluginEnv(env []dummycdv1alpha1.ApplicationPluginEnv) []*pb.PluginEnv {
+	var out []*pb.PluginEnv
+
+	for _, e := range env {
+		out = append(out, &pb.PluginEnv{Name: e.Name, Value: e.Value})
+	}
+
+	return out
+}
+
+func getHelmValuesFrom(references []dummycdv1alpha1.ApplicationHelmValuesReference) []*pb.HelmValuesReference {
+	var out []*pb.HelmValuesReference
+
+	for _, r := range references {
+		out = append(out, &pb.HelmValuesReference{Kind: r.Kind, Name: r.Name, Key: r.Key, Optional: r.Optional})
+	}
+
+	return out
+}
+
+func getHelmParameters(parameters []dummycdv1alpha1.ApplicationHelmParameter) []*pb.HelmParameter {
+	var out []*pb.HelmParameter
+
+	for _, p := range parameters {
+		out = append(out, &pb.HelmParameter{Name: p.Name, Value: p.Value, Type: p.Type})
+	}
+
+	return out
+}
+
+func newHelmParameters(parameters []*pb.HelmParameter) []dummycdv1alpha1.ApplicationHelmParameter {
+	var out []dummycdv1alpha1.ApplicationHelmParameter
+
+	for _, p := range parameters {
+		out = append(out, dummycdv1alpha1.ApplicationHelmParameter{Name: p.GetName(), Value: p.GetValue(), Type: p.GetType()})
+	}
+
+	return out
+}
+
+// getHelmValues returns inline values as JSON document, which is valid YAML as well
+func getHelmValues(values *runtime.RawExtension) string {
+	if values == nil {
+		return ""
+	}
+
+	return string(values.Raw)
+}
+
+func getIgnoreDifferences(differences []dummycdv1alpha1.ApplicationIgnoreDifference) []*pb.IgnoreDifference {
+	var out []*pb.IgnoreDifference
+
+	for _, d := range differences {
+		out = append(out, &pb.IgnoreDifference{
+			Group:                 d.Group,
+			Kind:                  d.Kind,
+			Name:                  d.Name,
+			Namespace:             d.Namespace,
+			JsonPointers:          d.JSONPointers,
+			JqPathExpressions:     d.JQPathExpressions,
+			ManagedFieldsManagers: d.ManagedFieldsManagers,
+		})
+	}
+
+	return out
+}
@@ -7,8 +7,9 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	log "github.com/sirupsen/logrus"
 	"github.com/yimgzz/dummy-cd/server/pkg/provider"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
 	"golang.org/x/net/context"
-	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/rest"
 	"os"
 	"path"
@@ -18,24 +19,35 @@ import (
 
 // Application holds git repository, git options and delivery provider
 type Application struct {
-	Name             string `json:"name"`
-	Namespace        string `json:"namespace"`
-	URL              string `json:"url"`
-	Reference        string `json:"reference"`
-	SparsePath       string `json:"sparsePath"`
-	storagePath      string
-	handledPath      string
-	CurrentRevision  plumbing.Hash
-	cloneOptions     *git.CloneOptions
-	checkoutOptions  *git.CheckoutOptions
-	pullOptions      *git.PullOptions
-	fetchOptions     *git.FetchOptions
-	logOptions       *git.LogOptions
-	RepositoryConfig *RepositoryConfig
-	repo             *git.Repository
-	Helm             *provider.HelmProvider
-	mutex            *sync.Mutex
-	deliveryProvider provider.DeliveryProvider
+	Name              string                      `json:"name"`
+	Namespace         string                      `json:"namespace"`
+	URL               string                      `json:"url"`
+	Reference         string                      `json:"reference"`
+	SparsePath        string                      `json:"sparsePath"`
+	SparsePaths       []string                    `json:"sparsePaths"`
+	Include           []string                    `json:"include"`
+	Exclude           []string                    `json:"exclude"`
+	Provider          string                      `json:"provider"`
+	IgnoreDifferences []provider.IgnoreDifference `json:"ignoreDifferences"`
+	storagePath       string
+	handledPath       string
+	sourcePaths       []string
+	libraryPaths      []string
+	fileFilter        *util.FileFilter
+	CurrentRevision   plumbing.Hash
+	cloneOptions      *git.CloneOptions
+	checkoutOptions   *git.CheckoutOptions
+	pullOptions       *git.PullOptions
+	fetchOptions      *git.FetchOptions
+	logOptions        *git.LogOptions
+	RepositoryConfig  *RepositoryConfig
+	repo              *git.Repository
+	Helm              *provider.HelmProvider
+	Raw               *provider.RawProvider
+	Jsonnet           *provider.JsonnetProvider
+	Plugin            *provider.PluginProvider
+	mutex             *sync.Mutex
+	deliveryProvider  provider.DeliveryProvider
 }
 
 func NewApplication(ctx context.Context, app *Application, restKubeConfig *rest.Config) (*Application, error) {
@@ -47,7 +59,25 @@ func NewApplication(ctx context.Context, app *Application, restKubeConfig *rest.
 	var err error
 
 	app.storagePath = path.Join(Workspace, app.Name)
-	app.handledPath = path.Join(app.storagePath, app.SparsePath)
+	app.sourcePaths = app.getSourcePaths()
+	app.handledPath = path.Join(app.storagePath, app.sourcePaths[0])
+
+	if app.Jsonnet == nil {
+		app.Jsonnet = &provider.JsonnetProvider{}
+	}
+
+	if app.Plugin == nil {
+		app.Plugin = &provider.PluginProvider{}
+	}
+
+	app.libraryPaths = app.getLibraryPaths()
+
+	app.fileFilter, err = util.NewFileFilter(app.Include, app.Exclude)
+
+	if err != nil {
+		app.logWithFields().Error(err)
+		return nil, err
+	}
 
 	referenceName := app.getLocalReferenceName()
 
@@ -58,20 +88,14 @@ func NewApplication(ctx context.Context, app *Application, restKubeConfig *rest.
 	app.pullOptions = app.RepositoryConfig.GetPullOptions(&referenceName)
 
 	app.checkoutOptions = &git.CheckoutOptions{
-		SparseCheckoutDirectories: []string{app.SparsePath},
+		SparseCheckoutDirectories: app.getCheckoutPaths(),
 		Keep:                      false,
 		Branch:                    referenceName,
 		Force:                     true,
 	}
 
 	app.logOptions = &git.LogOptions{
-		PathFilter: func(s string) bool {
-			if len(app.SparsePath) == 0 {
-				return true
-			} else {
-				return strings.HasPrefix(s, app.SparsePath)
-			}
-		},
+		PathFilter: app.matchSourceFile,
 	}
 
 	app.repo, err = NewGitRepository(ctx, &app.storagePath, app.cloneOptions, app.checkoutOptions)
@@ -80,33 +104,118 @@ func NewApplication(ctx context.Context, app *Application, restKubeConfig *rest.
 		return nil, err
 	}
 
-	_, err = chartutil.IsChartDir(app.handledPath)
+	resourcePaths := app.getHandledPaths()
+
+	var providerName string
+
+	app.deliveryProvider, providerName, err = provider.NewDeliveryProvider(app.Provider, &provider.ProviderOptions{
+		Ctx:               ctx,
+		SourcePath:        &app.handledPath,
+		SourcePaths:       &resourcePaths,
+		RepositoryPath:    &app.storagePath,
+		FileFilter:        app.fileFilter,
+		AppName:           &app.Name,
+		Namespace:         &app.Namespace,
+		KubeConfig:        restKubeConfig,
+		AppRevision:       &app.CurrentRevision,
+		Helm:              app.Helm,
+		Raw:               app.Raw,
+		Jsonnet:           app.Jsonnet,
+		Plugin:            app.Plugin,
+		IgnoreDifferences: app.IgnoreDifferences,
+	})
 
 	if err != nil {
-		app.deliveryProvider, err = provider.NewRawProvider(ctx, &app.Name, &app.handledPath, &app.Namespace, restKubeConfig, &app.CurrentRevision)
+		app.logWithFields().Error(err)
+		return nil, err
+	}
 
-		if err != nil {
-			app.logWithFields().Error(err)
-			return nil, err
+	app.logWithFields().Infof("delivery with %s provider", providerName)
+
+	return app, nil
+}
+
+func (a *Application) logWithFields() *log.Entry {
+	return log.WithFields(log.Fields{"app": a.Name, "revision": a.CurrentRevision.String()})
+}
+
+// getSourcePaths returns deduplicated sparse paths, the first one is used to detect the delivery provider
+func (a *Application) getSourcePaths() []string {
+	var sourcePaths []string
+
+	seen := make(map[string]bool)
+
+	for _, sourcePath := range append([]string{a.SparsePath}, a.SparsePaths...) {
+		sourcePath = strings.Trim(path.Clean("/"+sourcePath), "/")
+
+		if seen[sourcePath] || (len(sourcePath) == 0 && len(a.SparsePaths) > 0) {
+			continue
 		}
 
-		app.logWithFields().Info("delivery as raw k8s resources")
-	} else {
-		app.deliveryProvider, err = provider.NewHelmProvider(&app.Name, &app.handledPath, &app.Namespace, app.Helm, restKubeConfig, &app.CurrentRevision)
+		seen[sourcePath] = true
+		sourcePaths = append(sourcePaths, sourcePath)
+	}
 
-		if err != nil {
-			app.logWithFields().Error(err)
-			return nil, err
+	if len(sourcePaths) == 0 {
+		sourcePaths = []string{""}
+	}
+
+	return sourcePaths
+}
+
+func (a *Application) getHandledPaths() []string {
+	var handledPaths []string
+
+	for _, sourcePath := range a.sourcePaths {
+		handledPaths = append(handledPaths, path.Join(a.storagePath, sourcePath))
+	}
+
+	return handledPaths
+}
+
+// getLibraryPaths returns jsonnet library paths, they are checked out along with the source paths
+func (a *Application) getLibraryPaths() []string {
+	var libraryPaths []string
+
+	for _, jpath := range a.Jsonnet.JPaths {
+		jpath = strings.Trim(path.Clean("/"+jpath), "/")
+
+		if len(jpath) == 0 || util.ContainsString(a.sourcePaths, jpath) || util.ContainsString(libraryPaths, jpath) {
+			continue
 		}
 
-		app.logWithFields().Info("delivery as helm release")
+		libraryPaths = append(libraryPaths, jpath)
 	}
 
-	return app, nil
+	return libraryPaths
 }
 
-func (a *Application) logWithFields() *log.Entry {
-	return log.WithFields(log.Fields{"app": a.Name, "revision": a.CurrentRevision.String()})
+func (a *Application) getCheckoutPaths() []string {
+	return append(append([]string{}, a.sourcePaths...), a.libraryPaths...)
+}
+
+// matchSourceFile reports whether the repository file belongs to one of the source paths and passes the file filter,
+// files of library paths are matched without filter
+func (a *Application) matchSourceFile(file string) bool {
+	for _, libraryPath := range a.libraryPaths {
+		if util.IsSubPath(libraryPath, file) {
+			return true
+		}
+	}
+
+	for _, sourcePath := range a.sourcePaths {
+		if !util.IsSubPath(sourcePath, file) {
+			continue
+		}
+
+		relPath := strings.TrimPrefix(strings.TrimPrefix(file, sourcePath), "/")
+
+		if a.fileFilter.Match(relPath) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (a *Application) getLocalReferenceName() plumbing.ReferenceName {
@@ -117,6 +226,54 @@ func (a *Application) getRemoteReferenceName() plumbing.ReferenceName {
 	return plumbing.ReferenceName("refs/remotes/origin/" + a.Reference)
 }
 
+// Render returns desired objects of the current revision using DeliveryProvider
+func (a *Application) Render() ([]*unstructured.Unstructured, error) {
+	return a.deliveryProvider.Render()
+}
+
+// Diff compares desired and live objects using DeliveryProvider
+func (a *Application) Diff() ([]*provider.ResourceDiff, error) {
+	return a.deliveryProvider.Diff()
+}
+
+// Health returns health of live objects using DeliveryProvider
+func (a *Application) Health() (*provider.ApplicationHealth, error) {
+	return a.deliveryProvider.Health()
+}
+
+// Status returns sync status of the last delivery using DeliveryProvider
+func (a *Application) Status() *provider.ApplicationSync {
+	sync := a.deliveryProvider.Status()
+
+	if sync == nil {
+		return &provider.ApplicationSync{Status: provider.SyncStatusUnknown}
+	}
+
+	return sync
+}
+
+// ConfirmPrune allows deletion of the objects pending prune, if DeliveryProvider supports it
+func (a *Application) ConfirmPrune() error {
+	confirmer, ok := a.deliveryProvider.(provider.PruneConfirmer)
+
+	if !ok {
+		return util.ErrPruneNotSupported
+	}
+
+	return confirmer.ConfirmPrune()
+}
+
+// OverrideParameters replaces parameters overridden at runtime, if DeliveryProvider supports it
+func (a *Application) OverrideParameters(parameters []provider.HelmParameter) error {
+	overrider, ok := a.deliveryProvider.(provider.ParameterOverrider)
+
+	if !ok {
+		return util.ErrParametersNotSupported
+	}
+
+	return overrider.OverrideParameters(parameters)
+}
+
 // Uninstall the application using DeliveryProvider
 func (a *Application) Uninstall() error {
 	err := a.deliveryProvider.Uninstall()
@@ -193,7 +350,7 @@ func (a *Application) RunLifeCycle() error {
 		err = worktree.ResetSparsely(&git.ResetOptions{
 			Commit: remoteReference.Hash(),
 			Mode:   git.HardReset,
-		}, []string{a.SparsePath})
+		}, a.getCheckoutPaths())
 
 		if err != nil {
 			a.logWithFields().Error(err)
@@ -271,13 +428,7 @@ func (a *Application) RunLifeCycle() error {
 func (a *Application) getRevisionsWithPathFilter() (object.CommitIter, error) {
 
 	iter, err := a.repo.Log(&git.LogOptions{
-		PathFilter: func(s string) bool {
-			if len(a.SparsePath) == 0 {
-				return true
-			} else {
-				return strings.HasPrefix(s, a.SparsePath)
-			}
-		},
+		PathFilter: a.matchSourceFile,
 	})
 
 	if err != nil {
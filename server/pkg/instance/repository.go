@@ -222,7 +222,7 @@ func (r *RepositoryConfig) AddOrUpdateApplication(ctx context.Context, restKubeC
 	}
 
 	if cmp.Equal(r.Apps[appIndex], application,
-		cmpopts.IgnoreUnexported(Application{}), cmpopts.IgnoreTypes(RepositoryConfig{}, plumbing.Hash{}, provider.HelmProvider{})) {
+		cmpopts.IgnoreUnexported(Application{}, provider.HelmProvider{}, provider.HelmActionOptions{}, provider.RawProvider{}, provider.JsonnetProvider{}, provider.PluginProvider{}), cmpopts.IgnoreTypes(RepositoryConfig{}, plumbing.Hash{})) {
 		return util.NoErrAlreadyUpTodate
 	}
 
@@ -143,13 +143,21 @@ type Application struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace  string        `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Url        string        `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
-	Reference  string        `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
-	SparsePath string        `protobuf:"bytes,6,opt,name=sparsePath,proto3" json:"sparsePath,omitempty"`
-	Revision   *Revision     `protobuf:"bytes,7,opt,name=revision,proto3" json:"revision,omitempty"`
-	Helm       *HelmProvider `protobuf:"bytes,8,opt,name=helm,proto3" json:"helm,omitempty"`
+	Name              string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace         string              `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Url               string              `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Reference         string              `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	SparsePath        string              `protobuf:"bytes,6,opt,name=sparsePath,proto3" json:"sparsePath,omitempty"`
+	Revision          *Revision           `protobuf:"bytes,7,opt,name=revision,proto3" json:"revision,omitempty"`
+	Helm              *HelmProvider       `protobuf:"bytes,8,opt,name=helm,proto3" json:"helm,omitempty"`
+	SparsePaths       []string            `protobuf:"bytes,9,rep,name=sparsePaths,proto3" json:"sparsePaths,omitempty"`
+	Include           []string            `protobuf:"bytes,10,rep,name=include,proto3" json:"include,omitempty"`
+	Exclude           []string            `protobuf:"bytes,11,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	Raw               *RawProvider        `protobuf:"bytes,12,opt,name=raw,proto3" json:"raw,omitempty"`
+	Jsonnet           *JsonnetProvider    `protobuf:"bytes,13,opt,name=jsonnet,proto3" json:"jsonnet,omitempty"`
+	Provider          string              `protobuf:"bytes,14,opt,name=provider,proto3" json:"provider,omitempty"`
+	Plugin            *PluginProvider     `protobuf:"bytes,15,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	IgnoreDifferences []*IgnoreDifference `protobuf:"bytes,16,rep,name=ignoreDifferences,proto3" json:"ignoreDifferences,omitempty"`
 }
 
 func (x *Application) Reset() {
@@ -233,6 +241,62 @@ func (x *Application) GetHelm() *HelmProvider {
 	return nil
 }
 
+func (x *Application) GetSparsePaths() []string {
+	if x != nil {
+		return x.SparsePaths
+	}
+	return nil
+}
+
+func (x *Application) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *Application) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *Application) GetRaw() *RawProvider {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *Application) GetJsonnet() *JsonnetProvider {
+	if x != nil {
+		return x.Jsonnet
+	}
+	return nil
+}
+
+func (x *Application) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Application) GetPlugin() *PluginProvider {
+	if x != nil {
+		return x.Plugin
+	}
+	return nil
+}
+
+func (x *Application) GetIgnoreDifferences() []*IgnoreDifference {
+	if x != nil {
+		return x.IgnoreDifferences
+	}
+	return nil
+}
+
 type Revision struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -296,23 +360,1315 @@ type Revisions struct {
 	Items []*Revision `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (x *Revisions) Reset() {
-	*x = Revisions{}
+func (x *Revisions) Reset() {
+	*x = Revisions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revisions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revisions) ProtoMessage() {}
+
+func (x *Revisions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revisions.ProtoReflect.Descriptor instead.
+func (*Revisions) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Revisions) GetItems() []*Revision {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type HelmProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CheckValuesEqual bool                   `protobuf:"varint,1,opt,name=checkValuesEqual,proto3" json:"checkValuesEqual,omitempty"`
+	ReInstallRelease bool                   `protobuf:"varint,2,opt,name=reInstallRelease,proto3" json:"reInstallRelease,omitempty"`
+	CreateNamespace  bool                   `protobuf:"varint,3,opt,name=createNamespace,proto3" json:"createNamespace,omitempty"`
+	Atomic           bool                   `protobuf:"varint,4,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	IncludeCRDs      bool                   `protobuf:"varint,5,opt,name=includeCRDs,proto3" json:"includeCRDs,omitempty"`
+	ValuesFiles      []string               `protobuf:"bytes,6,rep,name=valuesFiles,proto3" json:"valuesFiles,omitempty"`
+	SelfHeal         bool                   `protobuf:"varint,7,opt,name=selfHeal,proto3" json:"selfHeal,omitempty"`
+	Values           string                 `protobuf:"bytes,8,opt,name=values,proto3" json:"values,omitempty"`
+	ValuesFrom       []*HelmValuesReference `protobuf:"bytes,9,rep,name=valuesFrom,proto3" json:"valuesFrom,omitempty"`
+	Parameters       []*HelmParameter       `protobuf:"bytes,10,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	PostRender       *HelmPostRender        `protobuf:"bytes,11,opt,name=postRender,proto3" json:"postRender,omitempty"`
+}
+
+func (x *HelmProvider) Reset() {
+	*x = HelmProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmProvider) ProtoMessage() {}
+
+func (x *HelmProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmProvider.ProtoReflect.Descriptor instead.
+func (*HelmProvider) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HelmProvider) GetCheckValuesEqual() bool {
+	if x != nil {
+		return x.CheckValuesEqual
+	}
+	return false
+}
+
+func (x *HelmProvider) GetReInstallRelease() bool {
+	if x != nil {
+		return x.ReInstallRelease
+	}
+	return false
+}
+
+func (x *HelmProvider) GetCreateNamespace() bool {
+	if x != nil {
+		return x.CreateNamespace
+	}
+	return false
+}
+
+func (x *HelmProvider) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *HelmProvider) GetIncludeCRDs() bool {
+	if x != nil {
+		return x.IncludeCRDs
+	}
+	return false
+}
+
+func (x *HelmProvider) GetValuesFiles() []string {
+	if x != nil {
+		return x.ValuesFiles
+	}
+	return nil
+}
+
+func (x *HelmProvider) GetSelfHeal() bool {
+	if x != nil {
+		return x.SelfHeal
+	}
+	return false
+}
+
+func (x *HelmProvider) GetValues() string {
+	if x != nil {
+		return x.Values
+	}
+	return ""
+}
+
+func (x *HelmProvider) GetValuesFrom() []*HelmValuesReference {
+	if x != nil {
+		return x.ValuesFrom
+	}
+	return nil
+}
+
+func (x *HelmProvider) GetParameters() []*HelmParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *HelmProvider) GetPostRender() *HelmPostRender {
+	if x != nil {
+		return x.PostRender
+	}
+	return nil
+}
+
+type HelmPostRender struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kustomize string   `protobuf:"bytes,1,opt,name=kustomize,proto3" json:"kustomize,omitempty"`
+	Plugin    string   `protobuf:"bytes,2,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	Args      []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+}
+
+func (x *HelmPostRender) Reset() {
+	*x = HelmPostRender{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmPostRender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmPostRender) ProtoMessage() {}
+
+func (x *HelmPostRender) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmPostRender.ProtoReflect.Descriptor instead.
+func (*HelmPostRender) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HelmPostRender) GetKustomize() string {
+	if x != nil {
+		return x.Kustomize
+	}
+	return ""
+}
+
+func (x *HelmPostRender) GetPlugin() string {
+	if x != nil {
+		return x.Plugin
+	}
+	return ""
+}
+
+func (x *HelmPostRender) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type HelmParameter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type  string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *HelmParameter) Reset() {
+	*x = HelmParameter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmParameter) ProtoMessage() {}
+
+func (x *HelmParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmParameter.ProtoReflect.Descriptor instead.
+func (*HelmParameter) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HelmParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmParameter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *HelmParameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type HelmValuesReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind     string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Key      string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Optional bool   `protobuf:"varint,4,opt,name=optional,proto3" json:"optional,omitempty"`
+}
+
+func (x *HelmValuesReference) Reset() {
+	*x = HelmValuesReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HelmValuesReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HelmValuesReference) ProtoMessage() {}
+
+func (x *HelmValuesReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HelmValuesReference.ProtoReflect.Descriptor instead.
+func (*HelmValuesReference) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *HelmValuesReference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *HelmValuesReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HelmValuesReference) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HelmValuesReference) GetOptional() bool {
+	if x != nil {
+		return x.Optional
+	}
+	return false
+}
+
+type RawProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecurseDepth            int32    `protobuf:"varint,1,opt,name=recurseDepth,proto3" json:"recurseDepth,omitempty"`
+	AllowedNamespaces       []string `protobuf:"bytes,2,rep,name=allowedNamespaces,proto3" json:"allowedNamespaces,omitempty"`
+	AllowedClusterResources []string `protobuf:"bytes,3,rep,name=allowedClusterResources,proto3" json:"allowedClusterResources,omitempty"`
+	ForceConflicts          bool     `protobuf:"varint,4,opt,name=forceConflicts,proto3" json:"forceConflicts,omitempty"`
+	SelfHeal                bool     `protobuf:"varint,5,opt,name=selfHeal,proto3" json:"selfHeal,omitempty"`
+	WaitHealthy             bool     `protobuf:"varint,6,opt,name=waitHealthy,proto3" json:"waitHealthy,omitempty"`
+	HealthTimeoutSeconds    int32    `protobuf:"varint,7,opt,name=healthTimeoutSeconds,proto3" json:"healthTimeoutSeconds,omitempty"`
+	Prune                   string   `protobuf:"bytes,8,opt,name=prune,proto3" json:"prune,omitempty"`
+	PruneLimitPercent       int32    `protobuf:"varint,9,opt,name=pruneLimitPercent,proto3" json:"pruneLimitPercent,omitempty"`
+	AllowAdoption           bool     `protobuf:"varint,10,opt,name=allowAdoption,proto3" json:"allowAdoption,omitempty"`
+	Replace                 bool     `protobuf:"varint,11,opt,name=replace,proto3" json:"replace,omitempty"`
+	ReplaceWait             bool     `protobuf:"varint,12,opt,name=replaceWait,proto3" json:"replaceWait,omitempty"`
+	Validate                bool     `protobuf:"varint,13,opt,name=validate,proto3" json:"validate,omitempty"`
+	Atomic                  bool     `protobuf:"varint,14,opt,name=atomic,proto3" json:"atomic,omitempty"`
+}
+
+func (x *RawProvider) Reset() {
+	*x = RawProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawProvider) ProtoMessage() {}
+
+func (x *RawProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawProvider.ProtoReflect.Descriptor instead.
+func (*RawProvider) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RawProvider) GetRecurseDepth() int32 {
+	if x != nil {
+		return x.RecurseDepth
+	}
+	return 0
+}
+
+func (x *RawProvider) GetAllowedNamespaces() []string {
+	if x != nil {
+		return x.AllowedNamespaces
+	}
+	return nil
+}
+
+func (x *RawProvider) GetAllowedClusterResources() []string {
+	if x != nil {
+		return x.AllowedClusterResources
+	}
+	return nil
+}
+
+func (x *RawProvider) GetForceConflicts() bool {
+	if x != nil {
+		return x.ForceConflicts
+	}
+	return false
+}
+
+func (x *RawProvider) GetSelfHeal() bool {
+	if x != nil {
+		return x.SelfHeal
+	}
+	return false
+}
+
+func (x *RawProvider) GetWaitHealthy() bool {
+	if x != nil {
+		return x.WaitHealthy
+	}
+	return false
+}
+
+func (x *RawProvider) GetHealthTimeoutSeconds() int32 {
+	if x != nil {
+		return x.HealthTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RawProvider) GetPrune() string {
+	if x != nil {
+		return x.Prune
+	}
+	return ""
+}
+
+func (x *RawProvider) GetPruneLimitPercent() int32 {
+	if x != nil {
+		return x.PruneLimitPercent
+	}
+	return 0
+}
+
+func (x *RawProvider) GetAllowAdoption() bool {
+	if x != nil {
+		return x.AllowAdoption
+	}
+	return false
+}
+
+func (x *RawProvider) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+func (x *RawProvider) GetReplaceWait() bool {
+	if x != nil {
+		return x.ReplaceWait
+	}
+	return false
+}
+
+func (x *RawProvider) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
+func (x *RawProvider) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+type JsonnetVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Code  bool   `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *JsonnetVariable) Reset() {
+	*x = JsonnetVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JsonnetVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonnetVariable) ProtoMessage() {}
+
+func (x *JsonnetVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonnetVariable.ProtoReflect.Descriptor instead.
+func (*JsonnetVariable) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JsonnetVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JsonnetVariable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *JsonnetVariable) GetCode() bool {
+	if x != nil {
+		return x.Code
+	}
+	return false
+}
+
+type JsonnetProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entrypoint string             `protobuf:"bytes,1,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	Jpaths     []string           `protobuf:"bytes,2,rep,name=jpaths,proto3" json:"jpaths,omitempty"`
+	ExtVars    []*JsonnetVariable `protobuf:"bytes,3,rep,name=extVars,proto3" json:"extVars,omitempty"`
+	Tlas       []*JsonnetVariable `protobuf:"bytes,4,rep,name=tlas,proto3" json:"tlas,omitempty"`
+}
+
+func (x *JsonnetProvider) Reset() {
+	*x = JsonnetProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JsonnetProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonnetProvider) ProtoMessage() {}
+
+func (x *JsonnetProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonnetProvider.ProtoReflect.Descriptor instead.
+func (*JsonnetProvider) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *JsonnetProvider) GetEntrypoint() string {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return ""
+}
+
+func (x *JsonnetProvider) GetJpaths() []string {
+	if x != nil {
+		return x.Jpaths
+	}
+	return nil
+}
+
+func (x *JsonnetProvider) GetExtVars() []*JsonnetVariable {
+	if x != nil {
+		return x.ExtVars
+	}
+	return nil
+}
+
+func (x *JsonnetProvider) GetTlas() []*JsonnetVariable {
+	if x != nil {
+		return x.Tlas
+	}
+	return nil
+}
+
+type PluginEnv struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PluginEnv) Reset() {
+	*x = PluginEnv{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PluginEnv) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginEnv) ProtoMessage() {}
+
+func (x *PluginEnv) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginEnv.ProtoReflect.Descriptor instead.
+func (*PluginEnv) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PluginEnv) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginEnv) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PluginProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Args []string     `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	Env  []*PluginEnv `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty"`
+}
+
+func (x *PluginProvider) Reset() {
+	*x = PluginProvider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PluginProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginProvider) ProtoMessage() {}
+
+func (x *PluginProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginProvider.ProtoReflect.Descriptor instead.
+func (*PluginProvider) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PluginProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginProvider) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *PluginProvider) GetEnv() []*PluginEnv {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type IgnoreDifference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group                 string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Kind                  string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name                  string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace             string   `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	JsonPointers          []string `protobuf:"bytes,5,rep,name=jsonPointers,proto3" json:"jsonPointers,omitempty"`
+	JqPathExpressions     []string `protobuf:"bytes,6,rep,name=jqPathExpressions,proto3" json:"jqPathExpressions,omitempty"`
+	ManagedFieldsManagers []string `protobuf:"bytes,7,rep,name=managedFieldsManagers,proto3" json:"managedFieldsManagers,omitempty"`
+}
+
+func (x *IgnoreDifference) Reset() {
+	*x = IgnoreDifference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IgnoreDifference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IgnoreDifference) ProtoMessage() {}
+
+func (x *IgnoreDifference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IgnoreDifference.ProtoReflect.Descriptor instead.
+func (*IgnoreDifference) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IgnoreDifference) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *IgnoreDifference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *IgnoreDifference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IgnoreDifference) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *IgnoreDifference) GetJsonPointers() []string {
+	if x != nil {
+		return x.JsonPointers
+	}
+	return nil
+}
+
+func (x *IgnoreDifference) GetJqPathExpressions() []string {
+	if x != nil {
+		return x.JqPathExpressions
+	}
+	return nil
+}
+
+func (x *IgnoreDifference) GetManagedFieldsManagers() []string {
+	if x != nil {
+		return x.ManagedFieldsManagers
+	}
+	return nil
+}
+
+type Manifests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *Manifests) Reset() {
+	*x = Manifests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Manifests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Manifests) ProtoMessage() {}
+
+func (x *Manifests) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Manifests.ProtoReflect.Descriptor instead.
+func (*Manifests) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Manifests) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ResourceDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string   `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace  string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Status     string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Message    string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Fields     []string `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *ResourceDiff) Reset() {
+	*x = ResourceDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceDiff) ProtoMessage() {}
+
+func (x *ResourceDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceDiff.ProtoReflect.Descriptor instead.
+func (*ResourceDiff) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResourceDiff) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResourceDiff) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ResourceDiffs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*ResourceDiff `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ResourceDiffs) Reset() {
+	*x = ResourceDiffs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceDiffs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceDiffs) ProtoMessage() {}
+
+func (x *ResourceDiffs) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceDiffs.ProtoReflect.Descriptor instead.
+func (*ResourceDiffs) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResourceDiffs) GetItems() []*ResourceDiff {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ResourceHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Status     string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Message    string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ResourceHealth) Reset() {
+	*x = ResourceHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceHealth) ProtoMessage() {}
+
+func (x *ResourceHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceHealth.ProtoReflect.Descriptor instead.
+func (*ResourceHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResourceHealth) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ResourceHealth) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceHealth) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceHealth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceHealth) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResourceHealth) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ApplicationHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string            `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Items  []*ResourceHealth `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *ApplicationHealth) Reset() {
+	*x = ApplicationHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplicationHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplicationHealth) ProtoMessage() {}
+
+func (x *ApplicationHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplicationHealth.ProtoReflect.Descriptor instead.
+func (*ApplicationHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ApplicationHealth) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ApplicationHealth) GetItems() []*ResourceHealth {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ResourceResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion string `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace  string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name       string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Operation  string `protobuf:"bytes,5,opt,name=operation,proto3" json:"operation,omitempty"`
+	Message    string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ResourceResult) Reset() {
+	*x = ResourceResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_pb_handler_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceResult) ProtoMessage() {}
+
+func (x *ResourceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceResult.ProtoReflect.Descriptor instead.
+func (*ResourceResult) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResourceResult) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ResourceResult) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceResult) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceResult) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ResourceResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SyncRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision   string           `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Status     string           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message    string           `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	StartedAt  string           `protobuf:"bytes,4,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	FinishedAt string           `protobuf:"bytes,5,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
+	Parameters []*HelmParameter `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty"`
+}
+
+func (x *SyncRecord) Reset() {
+	*x = SyncRecord{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_pb_handler_proto_msgTypes[4]
+		mi := &file_pkg_pb_handler_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Revisions) String() string {
+func (x *SyncRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Revisions) ProtoMessage() {}
+func (*SyncRecord) ProtoMessage() {}
 
-func (x *Revisions) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_pb_handler_proto_msgTypes[4]
+func (x *SyncRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,48 +1679,85 @@ func (x *Revisions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Revisions.ProtoReflect.Descriptor instead.
-func (*Revisions) Descriptor() ([]byte, []int) {
-	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SyncRecord.ProtoReflect.Descriptor instead.
+func (*SyncRecord) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Revisions) GetItems() []*Revision {
+func (x *SyncRecord) GetRevision() string {
 	if x != nil {
-		return x.Items
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *SyncRecord) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SyncRecord) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SyncRecord) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *SyncRecord) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *SyncRecord) GetParameters() []*HelmParameter {
+	if x != nil {
+		return x.Parameters
 	}
 	return nil
 }
 
-type HelmProvider struct {
+type ApplicationStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CheckValuesEqual bool     `protobuf:"varint,1,opt,name=checkValuesEqual,proto3" json:"checkValuesEqual,omitempty"`
-	ReInstallRelease bool     `protobuf:"varint,2,opt,name=reInstallRelease,proto3" json:"reInstallRelease,omitempty"`
-	CreateNamespace  bool     `protobuf:"varint,3,opt,name=createNamespace,proto3" json:"createNamespace,omitempty"`
-	Atomic           bool     `protobuf:"varint,4,opt,name=atomic,proto3" json:"atomic,omitempty"`
-	IncludeCRDs      bool     `protobuf:"varint,5,opt,name=includeCRDs,proto3" json:"includeCRDs,omitempty"`
-	ValuesFiles      []string `protobuf:"bytes,6,rep,name=valuesFiles,proto3" json:"valuesFiles,omitempty"`
+	Sync       string            `protobuf:"bytes,1,opt,name=sync,proto3" json:"sync,omitempty"`
+	Revision   *Revision         `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	Resources  []*ResourceDiff   `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
+	CheckedAt  string            `protobuf:"bytes,4,opt,name=checkedAt,proto3" json:"checkedAt,omitempty"`
+	Results    []*ResourceResult `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	Prunes     []*ResourceDiff   `protobuf:"bytes,6,rep,name=prunes,proto3" json:"prunes,omitempty"`
+	History    []*SyncRecord     `protobuf:"bytes,7,rep,name=history,proto3" json:"history,omitempty"`
+	Parameters []*HelmParameter  `protobuf:"bytes,8,rep,name=parameters,proto3" json:"parameters,omitempty"`
 }
 
-func (x *HelmProvider) Reset() {
-	*x = HelmProvider{}
+func (x *ApplicationStatus) Reset() {
+	*x = ApplicationStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_pb_handler_proto_msgTypes[5]
+		mi := &file_pkg_pb_handler_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HelmProvider) String() string {
+func (x *ApplicationStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HelmProvider) ProtoMessage() {}
+func (*ApplicationStatus) ProtoMessage() {}
 
-func (x *HelmProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_pb_handler_proto_msgTypes[5]
+func (x *ApplicationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_pb_handler_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,49 +1768,63 @@ func (x *HelmProvider) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HelmProvider.ProtoReflect.Descriptor instead.
-func (*HelmProvider) Descriptor() ([]byte, []int) {
-	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ApplicationStatus.ProtoReflect.Descriptor instead.
+func (*ApplicationStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *HelmProvider) GetCheckValuesEqual() bool {
+func (x *ApplicationStatus) GetSync() string {
 	if x != nil {
-		return x.CheckValuesEqual
+		return x.Sync
 	}
-	return false
+	return ""
 }
 
-func (x *HelmProvider) GetReInstallRelease() bool {
+func (x *ApplicationStatus) GetRevision() *Revision {
 	if x != nil {
-		return x.ReInstallRelease
+		return x.Revision
 	}
-	return false
+	return nil
 }
 
-func (x *HelmProvider) GetCreateNamespace() bool {
+func (x *ApplicationStatus) GetResources() []*ResourceDiff {
 	if x != nil {
-		return x.CreateNamespace
+		return x.Resources
 	}
-	return false
+	return nil
 }
 
-func (x *HelmProvider) GetAtomic() bool {
+func (x *ApplicationStatus) GetCheckedAt() string {
 	if x != nil {
-		return x.Atomic
+		return x.CheckedAt
 	}
-	return false
+	return ""
 }
 
-func (x *HelmProvider) GetIncludeCRDs() bool {
+func (x *ApplicationStatus) GetResults() []*ResourceResult {
 	if x != nil {
-		return x.IncludeCRDs
+		return x.Results
 	}
-	return false
+	return nil
 }
 
-func (x *HelmProvider) GetValuesFiles() []string {
+func (x *ApplicationStatus) GetPrunes() []*ResourceDiff {
 	if x != nil {
-		return x.ValuesFiles
+		return x.Prunes
+	}
+	return nil
+}
+
+func (x *ApplicationStatus) GetHistory() []*SyncRecord {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *ApplicationStatus) GetParameters() []*HelmParameter {
+	if x != nil {
+		return x.Parameters
 	}
 	return nil
 }
@@ -431,7 +1838,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_pb_handler_proto_msgTypes[6]
+		mi := &file_pkg_pb_handler_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -444,7 +1851,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_pb_handler_proto_msgTypes[6]
+	mi := &file_pkg_pb_handler_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +1864,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{6}
+	return file_pkg_pb_handler_proto_rawDescGZIP(), []int{23}
 }
 
 var File_pkg_pb_handler_proto protoreflect.FileDescriptor
@@ -477,7 +1884,7 @@ var file_pkg_pb_handler_proto_rawDesc = []byte{
 	0x79, 0x22, 0x35, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x73, 0x12, 0x25, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xdf, 0x01, 0x0a, 0x0b, 0x41, 0x70, 0x70,
+	0x6e, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x93, 0x04, 0x0a, 0x0b, 0x41, 0x70, 0x70,
 	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
 	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
@@ -491,57 +1898,282 @@ var file_pkg_pb_handler_proto_rawDesc = []byte{
 	0x62, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69,
 	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x68, 0x65, 0x6c, 0x6d, 0x18, 0x08, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x52, 0x04, 0x68, 0x65, 0x6c, 0x6d, 0x22, 0x38, 0x0a, 0x08, 0x52, 0x65,
-	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x2f, 0x0a, 0x09, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x22, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x05,
-	0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xec, 0x01, 0x0a, 0x0c, 0x48, 0x65, 0x6c, 0x6d, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x71, 0x75,
-	0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52,
-	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x28,
-	0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x74, 0x6f, 0x6d,
-	0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63,
-	0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x52, 0x44, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x52,
-	0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x46, 0x69, 0x6c, 0x65,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x46,
-	0x69, 0x6c, 0x65, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xff, 0x02,
-	0x0a, 0x07, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x63, 0x64, 0x12, 0x2c, 0x0a, 0x0d, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0e, 0x2e, 0x70, 0x62, 0x2e,
-	0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x0e, 0x2e, 0x70, 0x62,
-	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x1a, 0x09, 0x2e, 0x70, 0x62,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x16, 0x41, 0x64, 0x64, 0x4f,
-	0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x64, 0x65, 0x72, 0x52, 0x04, 0x68, 0x65, 0x6c, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x70,
+	0x61, 0x72, 0x73, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x12, 0x21, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x70, 0x62, 0x2e, 0x52, 0x61, 0x77, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x03,
+	0x72, 0x61, 0x77, 0x12, 0x2d, 0x0a, 0x07, 0x6a, 0x73, 0x6f, 0x6e, 0x6e, 0x65, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x73, 0x6f, 0x6e, 0x6e, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x07, 0x6a, 0x73, 0x6f, 0x6e, 0x6e,
+	0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2a,
+	0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x62, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x42, 0x0a, 0x11, 0x69, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x11, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x38,
+	0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2f, 0x0a, 0x09, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xc0, 0x03, 0x0a, 0x0c, 0x48, 0x65,
+	0x6c, 0x6d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x10, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x72, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x74,
+	0x6f, 0x6d, 0x69, 0x63, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43,
+	0x52, 0x44, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x43, 0x52, 0x44, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x66,
+	0x48, 0x65, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x66,
+	0x48, 0x65, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0a,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x31, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x48,
+	0x65, 0x6c, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70,
+	0x62, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x5a, 0x0a, 0x0e,
+	0x48, 0x65, 0x6c, 0x6d, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6b, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x4d, 0x0a, 0x0d, 0x48, 0x65, 0x6c, 0x6d,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x6b, 0x0a, 0x13, 0x48, 0x65, 0x6c, 0x6d, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x22, 0x8d, 0x04, 0x0a, 0x0b, 0x52, 0x61, 0x77, 0x50, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x65, 0x44,
+	0x65, 0x70, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x75,
+	0x72, 0x73, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x66,
+	0x48, 0x65, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x66,
+	0x48, 0x65, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x77, 0x61, 0x69, 0x74, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x61, 0x69, 0x74, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x32, 0x0a, 0x14, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
+	0x75, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x75, 0x6e, 0x65,
+	0x12, 0x2c, 0x0a, 0x11, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x70, 0x72, 0x75,
+	0x6e, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x24,
+	0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x64, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x64, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x57, 0x61, 0x69, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x57, 0x61, 0x69, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x74,
+	0x6f, 0x6d, 0x69, 0x63, 0x22, 0x4f, 0x0a, 0x0f, 0x4a, 0x73, 0x6f, 0x6e, 0x6e, 0x65, 0x74, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x0f, 0x4a, 0x73, 0x6f, 0x6e, 0x6e, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6a, 0x70, 0x61, 0x74, 0x68,
+	0x73, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x78, 0x74, 0x56, 0x61, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x73, 0x6f, 0x6e, 0x6e, 0x65, 0x74, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x07, 0x65, 0x78, 0x74, 0x56, 0x61, 0x72, 0x73,
+	0x12, 0x27, 0x0a, 0x04, 0x74, 0x6c, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x62, 0x2e, 0x4a, 0x73, 0x6f, 0x6e, 0x6e, 0x65, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x04, 0x74, 0x6c, 0x61, 0x73, 0x22, 0x35, 0x0a, 0x09, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x59, 0x0a, 0x0e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x03, 0x65, 0x6e,
+	0x76, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x45, 0x6e, 0x76, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x22, 0xf6, 0x01, 0x0a, 0x10,
+	0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x2c, 0x0a, 0x11, 0x6a, 0x71, 0x50, 0x61, 0x74, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x6a, 0x71, 0x50,
+	0x61, 0x74, 0x68, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34,
+	0x0a, 0x15, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x4d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x73, 0x22, 0x21, 0x0a, 0x09, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70,
+	0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x37, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x22, 0xa8, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x55, 0x0a, 0x11,
+	0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x31, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x65, 0x6c, 0x6d, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x22, 0xd4, 0x02, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x79, 0x6e, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x28, 0x0a, 0x08,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
+	0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x09, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x44, 0x69, 0x66, 0x66, 0x52, 0x06, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x07,
+	0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x70, 0x62, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x31, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e,
+	0x48, 0x65, 0x6c, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x32, 0xeb, 0x05, 0x0a, 0x07, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x63, 0x64, 0x12, 0x2c,
+	0x0a, 0x0d, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x1a,
+	0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x10,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a,
+	0x16, 0x41, 0x64, 0x64, 0x4f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e,
+	0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x09, 0x2e, 0x70, 0x62,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x17, 0x47, 0x65,
+	0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x6f, 0x75, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x0d, 0x2e, 0x70, 0x62, 0x2e,
+	0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0f, 0x44,
+	0x69, 0x66, 0x66, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x11, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x69, 0x66,
+	0x66, 0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x15, 0x2e,
+	0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0f,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x75, 0x6e, 0x65, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x1d, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
-	0x12, 0x31, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x70, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x1a, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x1b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x41, 0x70,
-	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x1a, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42,
-	0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x69,
-	0x6d, 0x67, 0x7a, 0x7a, 0x2f, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x2d, 0x63, 0x64, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79,
+	0x69, 0x6d, 0x67, 0x7a, 0x7a, 0x2f, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x2d, 0x63, 0x64, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -556,40 +2188,88 @@ func file_pkg_pb_handler_proto_rawDescGZIP() []byte {
 	return file_pkg_pb_handler_proto_rawDescData
 }
 
-var file_pkg_pb_handler_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pkg_pb_handler_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_pkg_pb_handler_proto_goTypes = []interface{}{
-	(*Repository)(nil),   // 0: pb.Repository
-	(*Applications)(nil), // 1: pb.Applications
-	(*Application)(nil),  // 2: pb.Application
-	(*Revision)(nil),     // 3: pb.Revision
-	(*Revisions)(nil),    // 4: pb.Revisions
-	(*HelmProvider)(nil), // 5: pb.HelmProvider
-	(*Empty)(nil),        // 6: pb.Empty
+	(*Repository)(nil),          // 0: pb.Repository
+	(*Applications)(nil),        // 1: pb.Applications
+	(*Application)(nil),         // 2: pb.Application
+	(*Revision)(nil),            // 3: pb.Revision
+	(*Revisions)(nil),           // 4: pb.Revisions
+	(*HelmProvider)(nil),        // 5: pb.HelmProvider
+	(*HelmPostRender)(nil),      // 6: pb.HelmPostRender
+	(*HelmParameter)(nil),       // 7: pb.HelmParameter
+	(*HelmValuesReference)(nil), // 8: pb.HelmValuesReference
+	(*RawProvider)(nil),         // 9: pb.RawProvider
+	(*JsonnetVariable)(nil),     // 10: pb.JsonnetVariable
+	(*JsonnetProvider)(nil),     // 11: pb.JsonnetProvider
+	(*PluginEnv)(nil),           // 12: pb.PluginEnv
+	(*PluginProvider)(nil),      // 13: pb.PluginProvider
+	(*IgnoreDifference)(nil),    // 14: pb.IgnoreDifference
+	(*Manifests)(nil),           // 15: pb.Manifests
+	(*ResourceDiff)(nil),        // 16: pb.ResourceDiff
+	(*ResourceDiffs)(nil),       // 17: pb.ResourceDiffs
+	(*ResourceHealth)(nil),      // 18: pb.ResourceHealth
+	(*ApplicationHealth)(nil),   // 19: pb.ApplicationHealth
+	(*ResourceResult)(nil),      // 20: pb.ResourceResult
+	(*SyncRecord)(nil),          // 21: pb.SyncRecord
+	(*ApplicationStatus)(nil),   // 22: pb.ApplicationStatus
+	(*Empty)(nil),               // 23: pb.Empty
 }
 var file_pkg_pb_handler_proto_depIdxs = []int32{
 	2,  // 0: pb.Applications.items:type_name -> pb.Application
 	3,  // 1: pb.Application.revision:type_name -> pb.Revision
 	5,  // 2: pb.Application.helm:type_name -> pb.HelmProvider
-	3,  // 3: pb.Revisions.items:type_name -> pb.Revision
-	0,  // 4: pb.dummycd.AddRepository:input_type -> pb.Repository
-	0,  // 5: pb.dummycd.DeleteRepository:input_type -> pb.Repository
-	2,  // 6: pb.dummycd.AddOrUpdateApplication:input_type -> pb.Application
-	2,  // 7: pb.dummycd.DeleteApplication:input_type -> pb.Application
-	6,  // 8: pb.dummycd.GetApplications:input_type -> pb.Empty
-	2,  // 9: pb.dummycd.GetApplicationRevisions:input_type -> pb.Application
-	2,  // 10: pb.dummycd.CheckoutApplicationRevision:input_type -> pb.Application
-	6,  // 11: pb.dummycd.AddRepository:output_type -> pb.Empty
-	6,  // 12: pb.dummycd.DeleteRepository:output_type -> pb.Empty
-	6,  // 13: pb.dummycd.AddOrUpdateApplication:output_type -> pb.Empty
-	6,  // 14: pb.dummycd.DeleteApplication:output_type -> pb.Empty
-	1,  // 15: pb.dummycd.GetApplications:output_type -> pb.Applications
-	4,  // 16: pb.dummycd.GetApplicationRevisions:output_type -> pb.Revisions
-	6,  // 17: pb.dummycd.CheckoutApplicationRevision:output_type -> pb.Empty
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	9,  // 3: pb.Application.raw:type_name -> pb.RawProvider
+	11, // 4: pb.Application.jsonnet:type_name -> pb.JsonnetProvider
+	13, // 5: pb.Application.plugin:type_name -> pb.PluginProvider
+	14, // 6: pb.Application.ignoreDifferences:type_name -> pb.IgnoreDifference
+	3,  // 7: pb.Revisions.items:type_name -> pb.Revision
+	8,  // 8: pb.HelmProvider.valuesFrom:type_name -> pb.HelmValuesReference
+	7,  // 9: pb.HelmProvider.parameters:type_name -> pb.HelmParameter
+	6,  // 10: pb.HelmProvider.postRender:type_name -> pb.HelmPostRender
+	10, // 11: pb.JsonnetProvider.extVars:type_name -> pb.JsonnetVariable
+	10, // 12: pb.JsonnetProvider.tlas:type_name -> pb.JsonnetVariable
+	12, // 13: pb.PluginProvider.env:type_name -> pb.PluginEnv
+	16, // 14: pb.ResourceDiffs.items:type_name -> pb.ResourceDiff
+	18, // 15: pb.ApplicationHealth.items:type_name -> pb.ResourceHealth
+	7,  // 16: pb.SyncRecord.parameters:type_name -> pb.HelmParameter
+	3,  // 17: pb.ApplicationStatus.revision:type_name -> pb.Revision
+	16, // 18: pb.ApplicationStatus.resources:type_name -> pb.ResourceDiff
+	20, // 19: pb.ApplicationStatus.results:type_name -> pb.ResourceResult
+	16, // 20: pb.ApplicationStatus.prunes:type_name -> pb.ResourceDiff
+	21, // 21: pb.ApplicationStatus.history:type_name -> pb.SyncRecord
+	7,  // 22: pb.ApplicationStatus.parameters:type_name -> pb.HelmParameter
+	0,  // 23: pb.dummycd.AddRepository:input_type -> pb.Repository
+	0,  // 24: pb.dummycd.DeleteRepository:input_type -> pb.Repository
+	2,  // 25: pb.dummycd.AddOrUpdateApplication:input_type -> pb.Application
+	2,  // 26: pb.dummycd.DeleteApplication:input_type -> pb.Application
+	23, // 27: pb.dummycd.GetApplications:input_type -> pb.Empty
+	2,  // 28: pb.dummycd.GetApplicationRevisions:input_type -> pb.Application
+	2,  // 29: pb.dummycd.CheckoutApplicationRevision:input_type -> pb.Application
+	2,  // 30: pb.dummycd.RenderApplication:input_type -> pb.Application
+	2,  // 31: pb.dummycd.DiffApplication:input_type -> pb.Application
+	2,  // 32: pb.dummycd.GetApplicationHealth:input_type -> pb.Application
+	2,  // 33: pb.dummycd.GetApplicationStatus:input_type -> pb.Application
+	2,  // 34: pb.dummycd.ConfirmApplicationPrune:input_type -> pb.Application
+	2,  // 35: pb.dummycd.OverrideApplicationParameters:input_type -> pb.Application
+	23, // 36: pb.dummycd.AddRepository:output_type -> pb.Empty
+	23, // 37: pb.dummycd.DeleteRepository:output_type -> pb.Empty
+	23, // 38: pb.dummycd.AddOrUpdateApplication:output_type -> pb.Empty
+	23, // 39: pb.dummycd.DeleteApplication:output_type -> pb.Empty
+	1,  // 40: pb.dummycd.GetApplications:output_type -> pb.Applications
+	4,  // 41: pb.dummycd.GetApplicationRevisions:output_type -> pb.Revisions
+	23, // 42: pb.dummycd.CheckoutApplicationRevision:output_type -> pb.Empty
+	15, // 43: pb.dummycd.RenderApplication:output_type -> pb.Manifests
+	17, // 44: pb.dummycd.DiffApplication:output_type -> pb.ResourceDiffs
+	19, // 45: pb.dummycd.GetApplicationHealth:output_type -> pb.ApplicationHealth
+	22, // 46: pb.dummycd.GetApplicationStatus:output_type -> pb.ApplicationStatus
+	23, // 47: pb.dummycd.ConfirmApplicationPrune:output_type -> pb.Empty
+	23, // 48: pb.dummycd.OverrideApplicationParameters:output_type -> pb.Empty
+	36, // [36:49] is the sub-list for method output_type
+	23, // [23:36] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_pkg_pb_handler_proto_init() }
@@ -671,6 +2351,210 @@ func file_pkg_pb_handler_proto_init() {
 			}
 		}
 		file_pkg_pb_handler_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmPostRender); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmParameter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HelmValuesReference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawProvider); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JsonnetVariable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JsonnetProvider); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginEnv); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginProvider); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IgnoreDifference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Manifests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceDiffs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplicationHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplicationStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_pb_handler_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Empty); i {
 			case 0:
 				return &v.state
@@ -689,7 +2573,7 @@ func file_pkg_pb_handler_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_pb_handler_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
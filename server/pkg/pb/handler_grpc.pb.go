@@ -19,13 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Dummycd_AddRepository_FullMethodName               = "/pb.dummycd/AddRepository"
-	Dummycd_DeleteRepository_FullMethodName            = "/pb.dummycd/DeleteRepository"
-	Dummycd_AddOrUpdateApplication_FullMethodName      = "/pb.dummycd/AddOrUpdateApplication"
-	Dummycd_DeleteApplication_FullMethodName           = "/pb.dummycd/DeleteApplication"
-	Dummycd_GetApplications_FullMethodName             = "/pb.dummycd/GetApplications"
-	Dummycd_GetApplicationRevisions_FullMethodName     = "/pb.dummycd/GetApplicationRevisions"
-	Dummycd_CheckoutApplicationRevision_FullMethodName = "/pb.dummycd/CheckoutApplicationRevision"
+	Dummycd_AddRepository_FullMethodName                 = "/pb.dummycd/AddRepository"
+	Dummycd_DeleteRepository_FullMethodName              = "/pb.dummycd/DeleteRepository"
+	Dummycd_AddOrUpdateApplication_FullMethodName        = "/pb.dummycd/AddOrUpdateApplication"
+	Dummycd_DeleteApplication_FullMethodName             = "/pb.dummycd/DeleteApplication"
+	Dummycd_GetApplications_FullMethodName               = "/pb.dummycd/GetApplications"
+	Dummycd_GetApplicationRevisions_FullMethodName       = "/pb.dummycd/GetApplicationRevisions"
+	Dummycd_CheckoutApplicationRevision_FullMethodName   = "/pb.dummycd/CheckoutApplicationRevision"
+	Dummycd_RenderApplication_FullMethodName             = "/pb.dummycd/RenderApplication"
+	Dummycd_DiffApplication_FullMethodName               = "/pb.dummycd/DiffApplication"
+	Dummycd_GetApplicationHealth_FullMethodName          = "/pb.dummycd/GetApplicationHealth"
+	Dummycd_GetApplicationStatus_FullMethodName          = "/pb.dummycd/GetApplicationStatus"
+	Dummycd_ConfirmApplicationPrune_FullMethodName       = "/pb.dummycd/ConfirmApplicationPrune"
+	Dummycd_OverrideApplicationParameters_FullMethodName = "/pb.dummycd/OverrideApplicationParameters"
 )
 
 // DummycdClient is the client API for Dummycd service.
@@ -39,6 +45,12 @@ type DummycdClient interface {
 	GetApplications(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Applications, error)
 	GetApplicationRevisions(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Revisions, error)
 	CheckoutApplicationRevision(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Empty, error)
+	RenderApplication(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Manifests, error)
+	DiffApplication(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ResourceDiffs, error)
+	GetApplicationHealth(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ApplicationHealth, error)
+	GetApplicationStatus(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ApplicationStatus, error)
+	ConfirmApplicationPrune(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Empty, error)
+	OverrideApplicationParameters(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type dummycdClient struct {
@@ -112,6 +124,60 @@ func (c *dummycdClient) CheckoutApplicationRevision(ctx context.Context, in *App
 	return out, nil
 }
 
+func (c *dummycdClient) RenderApplication(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Manifests, error) {
+	out := new(Manifests)
+	err := c.cc.Invoke(ctx, Dummycd_RenderApplication_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dummycdClient) DiffApplication(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ResourceDiffs, error) {
+	out := new(ResourceDiffs)
+	err := c.cc.Invoke(ctx, Dummycd_DiffApplication_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dummycdClient) GetApplicationHealth(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ApplicationHealth, error) {
+	out := new(ApplicationHealth)
+	err := c.cc.Invoke(ctx, Dummycd_GetApplicationHealth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dummycdClient) GetApplicationStatus(ctx context.Context, in *Application, opts ...grpc.CallOption) (*ApplicationStatus, error) {
+	out := new(ApplicationStatus)
+	err := c.cc.Invoke(ctx, Dummycd_GetApplicationStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dummycdClient) ConfirmApplicationPrune(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Dummycd_ConfirmApplicationPrune_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dummycdClient) OverrideApplicationParameters(ctx context.Context, in *Application, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Dummycd_OverrideApplicationParameters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DummycdServer is the server API for Dummycd service.
 // All implementations must embed UnimplementedDummycdServer
 // for forward compatibility
@@ -123,6 +189,12 @@ type DummycdServer interface {
 	GetApplications(context.Context, *Empty) (*Applications, error)
 	GetApplicationRevisions(context.Context, *Application) (*Revisions, error)
 	CheckoutApplicationRevision(context.Context, *Application) (*Empty, error)
+	RenderApplication(context.Context, *Application) (*Manifests, error)
+	DiffApplication(context.Context, *Application) (*ResourceDiffs, error)
+	GetApplicationHealth(context.Context, *Application) (*ApplicationHealth, error)
+	GetApplicationStatus(context.Context, *Application) (*ApplicationStatus, error)
+	ConfirmApplicationPrune(context.Context, *Application) (*Empty, error)
+	OverrideApplicationParameters(context.Context, *Application) (*Empty, error)
 	mustEmbedUnimplementedDummycdServer()
 }
 
@@ -151,6 +223,24 @@ func (UnimplementedDummycdServer) GetApplicationRevisions(context.Context, *Appl
 func (UnimplementedDummycdServer) CheckoutApplicationRevision(context.Context, *Application) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckoutApplicationRevision not implemented")
 }
+func (UnimplementedDummycdServer) RenderApplication(context.Context, *Application) (*Manifests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderApplication not implemented")
+}
+func (UnimplementedDummycdServer) DiffApplication(context.Context, *Application) (*ResourceDiffs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffApplication not implemented")
+}
+func (UnimplementedDummycdServer) GetApplicationHealth(context.Context, *Application) (*ApplicationHealth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationHealth not implemented")
+}
+func (UnimplementedDummycdServer) GetApplicationStatus(context.Context, *Application) (*ApplicationStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApplicationStatus not implemented")
+}
+func (UnimplementedDummycdServer) ConfirmApplicationPrune(context.Context, *Application) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmApplicationPrune not implemented")
+}
+func (UnimplementedDummycdServer) OverrideApplicationParameters(context.Context, *Application) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OverrideApplicationParameters not implemented")
+}
 func (UnimplementedDummycdServer) mustEmbedUnimplementedDummycdServer() {}
 
 // UnsafeDummycdServer may be embedded to opt out of forward compatibility for this service.
@@ -290,6 +380,114 @@ func _Dummycd_CheckoutApplicationRevision_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dummycd_RenderApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).RenderApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_RenderApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).RenderApplication(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dummycd_DiffApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).DiffApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_DiffApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).DiffApplication(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dummycd_GetApplicationHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).GetApplicationHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_GetApplicationHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).GetApplicationHealth(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dummycd_GetApplicationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).GetApplicationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_GetApplicationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).GetApplicationStatus(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dummycd_ConfirmApplicationPrune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).ConfirmApplicationPrune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_ConfirmApplicationPrune_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).ConfirmApplicationPrune(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dummycd_OverrideApplicationParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Application)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DummycdServer).OverrideApplicationParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dummycd_OverrideApplicationParameters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DummycdServer).OverrideApplicationParameters(ctx, req.(*Application))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Dummycd_ServiceDesc is the grpc.ServiceDesc for Dummycd service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -325,6 +523,30 @@ var Dummycd_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckoutApplicationRevision",
 			Handler:    _Dummycd_CheckoutApplicationRevision_Handler,
 		},
+		{
+			MethodName: "RenderApplication",
+			Handler:    _Dummycd_RenderApplication_Handler,
+		},
+		{
+			MethodName: "DiffApplication",
+			Handler:    _Dummycd_DiffApplication_Handler,
+		},
+		{
+			MethodName: "GetApplicationHealth",
+			Handler:    _Dummycd_GetApplicationHealth_Handler,
+		},
+		{
+			MethodName: "GetApplicationStatus",
+			Handler:    _Dummycd_GetApplicationStatus_Handler,
+		},
+		{
+			MethodName: "ConfirmApplicationPrune",
+			Handler:    _Dummycd_ConfirmApplicationPrune_Handler,
+		},
+		{
+			MethodName: "OverrideApplicationParameters",
+			Handler:    _Dummycd_OverrideApplicationParameters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/pb/handler.proto",
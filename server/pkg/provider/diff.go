@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var fieldNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// normalizeDesired returns copy of the desired object with Secret stringData merged into base64 data
+func normalizeDesired(desired *unstructured.Unstructured) *unstructured.Unstructured {
+	stringData, exist, _ := unstructured.NestedStringMap(desired.Object, "stringData")
+
+	if desired.GetKind() != "Secret" || desired.GroupVersionKind().Group != "" || !exist {
+		return desired
+	}
+
+	normalized := desired.DeepCopy()
+
+	data, _, _ := unstructured.NestedMap(normalized.Object, "data")
+
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	for key, value := range stringData {
+		data[key] = base64.StdEncoding.EncodeToString([]byte(value))
+	}
+
+	normalized.Object["data"] = data
+
+	unstructured.RemoveNestedField(normalized.Object, "stringData")
+
+	return normalized
+}
+
+// DiffObject returns paths of the fields set in the desired object which differ from the live object,
+// fields absent in the desired object, status and metadata besides labels and annotations are not compared
+func DiffObject(desired map[string]interface{}, live map[string]interface{}) []string {
+	var fields []string
+
+	for _, key := range sortedKeys(desired) {
+		switch key {
+		case "status":
+			continue
+		case "metadata":
+			desiredMeta, _ := desired[key].(map[string]interface{})
+			liveMeta, _ := live[key].(map[string]interface{})
+
+			for _, metaKey := range []string{"labels", "annotations"} {
+				if _, exist := desiredMeta[metaKey]; exist {
+					fields = append(fields, diffValue(appendFieldPath(".metadata", metaKey), desiredMeta[metaKey], liveMeta[metaKey])...)
+				}
+			}
+		default:
+			fields = append(fields, diffValue(appendFieldPath("", key), desired[key], live[key])...)
+		}
+	}
+
+	return fields
+}
+
+func diffValue(path string, desired interface{}, live interface{}) []string {
+	switch d := desired.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+
+		if !ok {
+			if live == nil && len(d) == 0 {
+				return nil
+			}
+
+			return []string{path}
+		}
+
+		var fields []string
+
+		for _, key := range sortedKeys(d) {
+			fields = append(fields, diffValue(appendFieldPath(path, key), d[key], l[key])...)
+		}
+
+		return fields
+	case []interface{}:
+		l, ok := live.([]interface{})
+
+		if !ok || len(l) != len(d) {
+			if live == nil && len(d) == 0 {
+				return nil
+			}
+
+			return []string{path}
+		}
+
+		var fields []string
+
+		for i := range d {
+			fields = append(fields, diffValue(path+"["+strconv.Itoa(i)+"]", d[i], l[i])...)
+		}
+
+		return fields
+	}
+
+	if equalScalar(desired, live) {
+		return nil
+	}
+
+	return []string{path}
+}
+
+// equalScalar compares numbers regardless of the decoded type and resource quantities regardless of their format
+// like 0.5 and 500m or 1Gi and 1024Mi, zero desired value equals to the absent live value
+func equalScalar(desired interface{}, live interface{}) bool {
+	if live == nil {
+		return reflect.ValueOf(desired).IsZero()
+	}
+
+	desiredNumber, desiredIsNumber := toFloat(desired)
+	liveNumber, liveIsNumber := toFloat(live)
+
+	if desiredIsNumber && liveIsNumber {
+		return desiredNumber == liveNumber
+	}
+
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+
+	desiredQuantity, desiredIsQuantity := toQuantity(desired)
+	liveQuantity, liveIsQuantity := toQuantity(live)
+
+	return desiredIsQuantity && liveIsQuantity && desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+// toQuantity parses strings and numbers as resource quantity
+func toQuantity(value interface{}) (resource.Quantity, bool) {
+	if number, isNumber := toFloat(value); isNumber {
+		value = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+
+	s, ok := value.(string)
+
+	if !ok {
+		return resource.Quantity{}, false
+	}
+
+	quantity, err := resource.ParseQuantity(s)
+
+	return quantity, err == nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// appendFieldPath appends the key to jq-style path, keys which are not identifiers are quoted
+func appendFieldPath(path string, key string) string {
+	if fieldNameRegex.MatchString(key) {
+		return path + "." + key
+	}
+
+	return path + "[" + strconv.Quote(key) + "]"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEqualScalar(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired interface{}
+		live    interface{}
+		want    bool
+	}{
+		{"integer and float", int64(1), float64(1), true},
+		{"cpu number and string", int64(1), "1", true},
+		{"cpu fraction and millis", 0.5, "500m", true},
+		{"cpu millis and fraction", "500m", "0.5", true},
+		{"memory binary units", "1Gi", "1024Mi", true},
+		{"memory changed", "1Gi", "512Mi", false},
+		{"cpu changed", "2", int64(1), false},
+		{"strings", "nginx", "nginx", true},
+		{"strings changed", "nginx:1", "nginx:2", false},
+		{"boolean and string", true, "true", false},
+		{"zero desired and absent live", int64(0), nil, true},
+		{"desired and absent live", "1", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalScalar(tt.desired, tt.live); got != tt.want {
+				t.Errorf("equalScalar(%#v, %#v) = %v, want %v", tt.desired, tt.live, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffResource(t *testing.T) {
+	const deployment = "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, labels: {app: a}}\n"
+
+	tests := []struct {
+		name    string
+		desired string
+		live    string
+		want    []string
+	}{
+		{
+			name: "resource quantities",
+			desired: deployment + "spec: {template: {spec: {containers: [{name: a, resources: " +
+				"{limits: {cpu: 1, memory: 1Gi}, requests: {cpu: 0.5, memory: 512Mi}}}]}}}\n",
+			live: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, labels: {app: a}, uid: b}\n" +
+				"spec: {template: {spec: {containers: [{name: a, resources: " +
+				"{limits: {cpu: '1', memory: 1024Mi}, requests: {cpu: 500m, memory: 512Mi}}}]}}}\nstatus: {replicas: 1}\n",
+		},
+		{
+			name: "nested drift",
+			desired: deployment + "spec: {replicas: 2, template: {spec: {containers: [{name: a, resources: " +
+				"{limits: {cpu: 1}}}]}}}\n",
+			live: deployment + "spec: {replicas: 1, template: {spec: {containers: [{name: a, resources: " +
+				"{limits: {cpu: 2}}}]}}}\n",
+			want: []string{".spec.replicas", ".spec.template.spec.containers[0].resources.limits.cpu"},
+		},
+		{
+			name:    "label drift",
+			desired: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {app.kubernetes.io/name: a}}\n",
+			live:    "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {app.kubernetes.io/name: b}}\n",
+			want:    []string{`.metadata.labels["app.kubernetes.io/name"]`},
+		},
+		{
+			name:    "secret string data",
+			desired: "apiVersion: v1\nkind: Secret\nmetadata: {name: a}\nstringData: {password: secret}\n",
+			live:    "apiVersion: v1\nkind: Secret\nmetadata: {name: a}\ndata: {password: c2VjcmV0}\n",
+		},
+		{
+			name:    "secret string data changed",
+			desired: "apiVersion: v1\nkind: Secret\nmetadata: {name: a}\nstringData: {password: changed}\n",
+			live:    "apiVersion: v1\nkind: Secret\nmetadata: {name: a}\ndata: {password: c2VjcmV0}\n",
+			want:    []string{".data.password"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffResource(newTestObject(t, tt.desired), newTestObject(t, tt.live), nil)
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("DiffResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
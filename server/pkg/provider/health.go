@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"strings"
+	"time"
+)
+
+var healthTimeout = flag.Duration("health-timeout", 5*time.Minute, "default timeout to wait for resources to become healthy on delivery")
+
+// GetResourceHealth returns health of the live object by its kind, objects of unknown kinds are assessed by Ready condition
+func GetResourceHealth(ctx context.Context, dynamicClient dynamic.Interface, live *unstructured.Unstructured) (HealthStatus, string, error) {
+	if live == nil {
+		return HealthStatusMissing, "object not found", nil
+	}
+
+	if live.GetDeletionTimestamp() != nil {
+		return HealthStatusProgressing, "object is being deleted", nil
+	}
+
+	gvk := live.GroupVersionKind()
+
+	switch {
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		status, message := getDeploymentHealth(live)
+		return status, message, nil
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		status, message := getStatefulSetHealth(live)
+		return status, message, nil
+	case gvk.Group == "apps" && gvk.Kind == "DaemonSet":
+		status, message := getDaemonSetHealth(live)
+		return status, message, nil
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+		status, message := getJobHealth(live)
+		return status, message, nil
+	case gvk.Group == "" && gvk.Kind == "Pod":
+		status, message := getPodHealth(live)
+		return status, message, nil
+	case gvk.Group == "" && gvk.Kind == "PersistentVolumeClaim":
+		status, message := getPersistentVolumeClaimHealth(live)
+		return status, message, nil
+	case gvk.Group == "" && gvk.Kind == "Service":
+		status, message := getServiceHealth(live)
+		return status, message, nil
+	case gvk.Group == "" && gvk.Kind == "Namespace":
+		if phase, _, _ := unstructured.NestedString(live.Object, "status", "phase"); phase != "Active" {
+			return HealthStatusProgressing, fmt.Sprintf("namespace is %s", phase), nil
+		}
+
+		return HealthStatusHealthy, "", nil
+	case gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition":
+		if !hasCondition(live, "Established", "True") {
+			return HealthStatusProgressing, "waiting for CRD to be established", nil
+		}
+
+		return HealthStatusHealthy, "", nil
+	}
+
+	status, message := getConditionsHealth(live)
+
+	return status, message, nil
+}
+
+// isGenerationObserved reports whether controller has seen the last spec of the object
+func isGenerationObserved(live *unstructured.Unstructured) bool {
+	observedGeneration, exist, _ := unstructured.NestedInt64(live.Object, "status", "observedGeneration")
+
+	return !exist || observedGeneration >= live.GetGeneration()
+}
+
+func getInt64(obj *unstructured.Unstructured, defaultValue int64, fields ...string) int64 {
+	value, exist, _ := unstructured.NestedInt64(obj.Object, fields...)
+
+	if !exist {
+		return defaultValue
+	}
+
+	return value
+}
+
+func getCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+
+		if ok && condition["type"] == conditionType {
+			return condition
+		}
+	}
+
+	return nil
+}
+
+func getDeploymentHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if paused, _, _ := unstructured.NestedBool(live.Object, "spec", "paused"); paused {
+		return HealthStatusHealthy, "deployment is paused"
+	}
+
+	if !isGenerationObserved(live) {
+		return HealthStatusProgressing, "waiting for rollout to be observed"
+	}
+
+	if condition := getCondition(live, "Progressing"); condition != nil && condition["reason"] == "ProgressDeadlineExceeded" {
+		return HealthStatusDegraded, fmt.Sprintf("deployment exceeded its progress deadline: %v", condition["message"])
+	}
+
+	replicas := getInt64(live, 1, "spec", "replicas")
+	updatedReplicas := getInt64(live, 0, "status", "updatedReplicas")
+	statusReplicas := getInt64(live, 0, "status", "replicas")
+	availableReplicas := getInt64(live, 0, "status", "availableReplicas")
+
+	switch {
+	case updatedReplicas < replicas:
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d replicas updated", updatedReplicas, replicas)
+	case statusReplicas > updatedReplicas:
+		return HealthStatusProgressing, fmt.Sprintf("%d old replicas pending termination", statusReplicas-updatedReplicas)
+	case availableReplicas < updatedReplicas:
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d updated replicas available", availableReplicas, updatedReplicas)
+	}
+
+	return HealthStatusHealthy, ""
+}
+
+func getStatefulSetHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if !isGenerationObserved(live) {
+		return HealthStatusProgressing, "waiting for rollout to be observed"
+	}
+
+	if strategy, _, _ := unstructured.NestedString(live.Object, "spec", "updateStrategy", "type"); strategy == "OnDelete" {
+		return HealthStatusHealthy, "updateStrategy is OnDelete"
+	}
+
+	replicas := getInt64(live, 1, "spec", "replicas")
+	readyReplicas := getInt64(live, 0, "status", "readyReplicas")
+
+	if readyReplicas < replicas {
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d replicas ready", readyReplicas, replicas)
+	}
+
+	partition := getInt64(live, 0, "spec", "updateStrategy", "rollingUpdate", "partition")
+	updatedReplicas := getInt64(live, 0, "status", "updatedReplicas")
+
+	if partition > 0 {
+		if updatedReplicas < replicas-partition {
+			return HealthStatusProgressing, fmt.Sprintf("%d of %d partitioned replicas updated", updatedReplicas, replicas-partition)
+		}
+
+		return HealthStatusHealthy, ""
+	}
+
+	currentRevision, _, _ := unstructured.NestedString(live.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(live.Object, "status", "updateRevision")
+
+	if currentRevision != updateRevision {
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d replicas updated", updatedReplicas, replicas)
+	}
+
+	return HealthStatusHealthy, ""
+}
+
+func getDaemonSetHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if !isGenerationObserved(live) {
+		return HealthStatusProgressing, "waiting for rollout to be observed"
+	}
+
+	if strategy, _, _ := unstructured.NestedString(live.Object, "spec", "updateStrategy", "type"); strategy == "OnDelete" {
+		return HealthStatusHealthy, "updateStrategy is OnDelete"
+	}
+
+	desired := getInt64(live, 0, "status", "desiredNumberScheduled")
+	updated := getInt64(live, 0, "status", "updatedNumberScheduled")
+	available := getInt64(live, 0, "status", "numberAvailable")
+
+	switch {
+	case updated < desired:
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d pods updated", updated, desired)
+	case available < desired:
+		return HealthStatusProgressing, fmt.Sprintf("%d of %d updated pods available", available, desired)
+	}
+
+	return HealthStatusHealthy, ""
+}
+
+func getJobHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if condition := getCondition(live, "Failed"); condition != nil && condition["status"] == "True" {
+		return HealthStatusDegraded, fmt.Sprintf("job failed: %v", condition["message"])
+	}
+
+	if condition := getCondition(live, "Complete"); condition != nil && condition["status"] == "True" {
+		return HealthStatusHealthy, ""
+	}
+
+	if suspend, _, _ := unstructured.NestedBool(live.Object, "spec", "suspend"); suspend {
+		return HealthStatusHealthy, "job is suspended"
+	}
+
+	return HealthStatusProgressing, "waiting for job to complete"
+}
+
+func getPodHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	phase, _, _ := unstructured.NestedString(live.Object, "status", "phase")
+
+	switch phase {
+	case "Succeeded":
+		return HealthStatusHealthy, ""
+	case "Failed":
+		message, _, _ := unstructured.NestedString(live.Object, "status", "message")
+		return HealthStatusDegraded, fmt.Sprintf("pod failed: %s", message)
+	}
+
+	containerStatuses, _, _ := unstructured.NestedSlice(live.Object, "status", "containerStatuses")
+
+	for _, c := range containerStatuses {
+		containerStatus, ok := c.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		reason, _, _ := unstructured.NestedString(containerStatus, "state", "waiting", "reason")
+
+		switch reason {
+		case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "InvalidImageName":
+			return HealthStatusDegraded, fmt.Sprintf("container %v: %s", containerStatus["name"], reason)
+		}
+	}
+
+	if phase == "Running" && hasCondition(live, "Ready", "True") {
+		return HealthStatusHealthy, ""
+	}
+
+	return HealthStatusProgressing, fmt.Sprintf("pod is %s", phase)
+}
+
+func getPersistentVolumeClaimHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	phase, _, _ := unstructured.NestedString(live.Object, "status", "phase")
+
+	switch phase {
+	case "Bound":
+		return HealthStatusHealthy, ""
+	case "Lost":
+		return HealthStatusDegraded, "claim lost its volume"
+	}
+
+	return HealthStatusProgressing, fmt.Sprintf("claim is %s", phase)
+}
+
+// getServiceHealth checks load balancer ingress, other services are healthy regardless of their endpoints,
+// so services of scaled down workloads do not make the application progressing
+func getServiceHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if serviceType, _, _ := unstructured.NestedString(live.Object, "spec", "type"); serviceType != "LoadBalancer" {
+		return HealthStatusHealthy, ""
+	}
+
+	if ingress, _, _ := unstructured.NestedSlice(live.Object, "status", "loadBalancer", "ingress"); len(ingress) == 0 {
+		return HealthStatusProgressing, "waiting for load balancer"
+	}
+
+	return HealthStatusHealthy, ""
+}
+
+// isTerminalReason reports whether the reason of Ready=False condition means the controller gave up reconciling
+func isTerminalReason(reason string) bool {
+	return strings.HasSuffix(reason, "Failed") || strings.HasSuffix(reason, "Error")
+}
+
+// getConditionsHealth assesses objects by Ready and Stalled conditions, objects without them are healthy when their generation is observed,
+// Ready=False is progressing while the object reconciles and degraded only with a terminal reason or Stalled=True
+func getConditionsHealth(live *unstructured.Unstructured) (HealthStatus, string) {
+	if condition := getCondition(live, "Stalled"); condition != nil && condition["status"] == "True" {
+		return HealthStatusDegraded, fmt.Sprintf("stalled: %v: %v", condition["reason"], condition["message"])
+	}
+
+	if !isGenerationObserved(live) {
+		return HealthStatusProgressing, "waiting for spec to be observed"
+	}
+
+	condition := getCondition(live, "Ready")
+
+	if condition == nil {
+		return HealthStatusHealthy, ""
+	}
+
+	message := fmt.Sprintf("%v: %v", condition["reason"], condition["message"])
+
+	switch condition["status"] {
+	case "True":
+		return HealthStatusHealthy, ""
+	case "False":
+		if reason, _ := condition["reason"].(string); isTerminalReason(reason) {
+			return HealthStatusDegraded, message
+		}
+	}
+
+	return HealthStatusProgressing, message
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetResourceHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     HealthStatus
+	}{
+		{
+			name:     "deployment paused",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a}\nspec: {paused: true}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name:     "deployment generation not observed",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, generation: 3}\nstatus: {observedGeneration: 2}\n",
+			want:     HealthStatusProgressing,
+		},
+		{
+			name: "deployment progress deadline exceeded",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a}\n" +
+				"status: {conditions: [{type: Progressing, status: 'False', reason: ProgressDeadlineExceeded}]}\n",
+			want: HealthStatusDegraded,
+		},
+		{
+			name: "deployment old replicas terminating",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a}\nspec: {replicas: 1}\n" +
+				"status: {replicas: 2, updatedReplicas: 1, availableReplicas: 1}\n",
+			want: HealthStatusProgressing,
+		},
+		{
+			name: "statefulset revision not updated",
+			manifest: "apiVersion: apps/v1\nkind: StatefulSet\nmetadata: {name: a}\nspec: {replicas: 1}\n" +
+				"status: {readyReplicas: 1, currentRevision: a-1, updateRevision: a-2}\n",
+			want: HealthStatusProgressing,
+		},
+		{
+			name: "statefulset partition updated",
+			manifest: "apiVersion: apps/v1\nkind: StatefulSet\nmetadata: {name: a}\n" +
+				"spec: {replicas: 3, updateStrategy: {rollingUpdate: {partition: 2}}}\n" +
+				"status: {readyReplicas: 3, updatedReplicas: 1, currentRevision: a-1, updateRevision: a-2}\n",
+			want: HealthStatusHealthy,
+		},
+		{
+			name: "daemonset pods not available",
+			manifest: "apiVersion: apps/v1\nkind: DaemonSet\nmetadata: {name: a}\n" +
+				"status: {desiredNumberScheduled: 2, updatedNumberScheduled: 2, numberAvailable: 1}\n",
+			want: HealthStatusProgressing,
+		},
+		{
+			name:     "job suspended",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nspec: {suspend: true}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name: "pod crash loop",
+			manifest: "apiVersion: v1\nkind: Pod\nmetadata: {name: a}\n" +
+				"status: {phase: Running, containerStatuses: [{name: a, state: {waiting: {reason: CrashLoopBackOff}}}]}\n",
+			want: HealthStatusDegraded,
+		},
+		{
+			name: "pod running ready",
+			manifest: "apiVersion: v1\nkind: Pod\nmetadata: {name: a}\n" +
+				"status: {phase: Running, conditions: [{type: Ready, status: 'True'}]}\n",
+			want: HealthStatusHealthy,
+		},
+		{
+			name:     "pvc lost",
+			manifest: "apiVersion: v1\nkind: PersistentVolumeClaim\nmetadata: {name: a}\nstatus: {phase: Lost}\n",
+			want:     HealthStatusDegraded,
+		},
+		{
+			name:     "pvc pending",
+			manifest: "apiVersion: v1\nkind: PersistentVolumeClaim\nmetadata: {name: a}\nstatus: {phase: Pending}\n",
+			want:     HealthStatusProgressing,
+		},
+		{
+			name:     "object being deleted",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, deletionTimestamp: '2023-01-01T00:00:00Z'}\n",
+			want:     HealthStatusProgressing,
+		},
+		{
+			name:     "custom resource without conditions",
+			manifest: "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: a}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name: "custom resource reconciling",
+			manifest: "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: a}\n" +
+				"status: {conditions: [{type: Ready, status: 'False', reason: Reconciling}]}\n",
+			want: HealthStatusProgressing,
+		},
+		{
+			name: "custom resource failed",
+			manifest: "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: a}\n" +
+				"status: {conditions: [{type: Ready, status: 'False', reason: InstallFailed}]}\n",
+			want: HealthStatusDegraded,
+		},
+		{
+			name: "custom resource stalled",
+			manifest: "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: a, generation: 2}\n" +
+				"status: {observedGeneration: 2, conditions: [{type: Ready, status: 'False', reason: Waiting}, {type: Stalled, status: 'True'}]}\n",
+			want: HealthStatusDegraded,
+		},
+		{
+			name: "custom resource ready",
+			manifest: "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: a}\n" +
+				"status: {conditions: [{type: Ready, status: 'True'}]}\n",
+			want: HealthStatusHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message, err := GetResourceHealth(context.Background(), nil, newTestObject(t, tt.manifest))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetResourceHealth() = %s (%s), want %s", got, message, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetServiceHealth(t *testing.T) {
+	const service = "apiVersion: v1\nkind: Service\nmetadata: {name: web, namespace: default}\n"
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     HealthStatus
+	}{
+		{
+			name:     "without selector",
+			manifest: service + "spec: {ports: [{port: 80}]}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name:     "cluster ip without endpoints",
+			manifest: service + "spec: {type: ClusterIP, selector: {app: web}}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name:     "load balancer without ingress",
+			manifest: service + "spec: {type: LoadBalancer, selector: {app: web}}\n",
+			want:     HealthStatusProgressing,
+		},
+		{
+			name:     "load balancer with ingress",
+			manifest: service + "spec: {type: LoadBalancer, selector: {app: web}}\nstatus: {loadBalancer: {ingress: [{ip: 10.0.0.1}]}}\n",
+			want:     HealthStatusHealthy,
+		},
+		{
+			name:     "external name",
+			manifest: service + "spec: {type: ExternalName, externalName: example.com}\n",
+			want:     HealthStatusHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message, err := GetResourceHealth(context.Background(), nil, newTestObject(t, tt.manifest))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetResourceHealth() = %s (%s), want %s", got, message, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewApplicationHealth(t *testing.T) {
+	newItem := func(status HealthStatus) *ResourceHealth {
+		return &ResourceHealth{Status: status}
+	}
+
+	tests := []struct {
+		name  string
+		items []*ResourceHealth
+		want  HealthStatus
+	}{
+		{"empty", nil, HealthStatusHealthy},
+		{"progressing", []*ResourceHealth{newItem(HealthStatusHealthy), newItem(HealthStatusProgressing)}, HealthStatusProgressing},
+		{"degraded", []*ResourceHealth{newItem(HealthStatusMissing), newItem(HealthStatusDegraded), newItem(HealthStatusProgressing)}, HealthStatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewApplicationHealth(tt.items).Status; got != tt.want {
+				t.Errorf("NewApplicationHealth() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
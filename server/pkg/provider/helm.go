@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"github.com/go-git/go-git/v5/plumbing"
 	log "github.com/sirupsen/logrus"
 	"github.com/yimgzz/dummy-cd/server/pkg/util"
@@ -14,7 +16,10 @@ import (
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/release"
 	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"os"
 	"path"
@@ -33,7 +38,9 @@ type HelmActionOptions struct {
 	CreateNamespace  bool
 	Atomic           bool
 	IncludeCRDs      bool
-	actionTimeout    time.Duration
+	// SelfHeal upgrades the release when objects of the release manifest drifted from the live ones
+	SelfHeal      bool
+	actionTimeout time.Duration
 }
 
 type HelmProvider struct {
@@ -46,9 +53,26 @@ type HelmProvider struct {
 	releaseName   *string
 	chartPath     *string
 	ValueFiles    []string
+	// ValuesFrom are merged over the value files in their order
+	ValuesFrom []HelmValuesReference
+	// Values is inline YAML document merged over all other values
+	Values string
+	// Parameters are applied over all values like --set flags, runtime overrides replace the ones of the same name
+	Parameters []HelmParameter
+	overrides  []HelmParameter
+	// PostRender changes the rendered manifest of install, upgrade, render and diff
+	PostRender    HelmPostRender
+	valueFiles    []string
 	chartValues   map[string]interface{}
 	namespace     *string
 	mutex         *sync.Mutex
+	clientSet     *kubernetes.Clientset
+	dynamicClient *dynamic.DynamicClient
+	mapper        *ResourceMapper
+	sync          *ApplicationSync
+	statusMutex   sync.Mutex
+	history       syncHistory
+	ignoreRules   []*ignoreRule
 }
 
 func NewHelmKubernetesConfig(restKubeConfig *rest.Config, namespace *string) *genericclioptions.ConfigFlags {
@@ -106,7 +130,8 @@ func NewHelmChart(manager *downloader.Manager, chartPath *string) (*chart.Chart,
 
 }
 
-func NewHelmChartValues(settings *cli.EnvSettings, valueFiles *[]string) (map[string]interface{}, error) {
+// NewHelmChartValues merges the value files, the overrides in their order over them and the parameters over all of them
+func NewHelmChartValues(settings *cli.EnvSettings, valueFiles *[]string, parameters *values.Options, overrides ...map[string]interface{}) (map[string]interface{}, error) {
 	options := &values.Options{
 		ValueFiles: *valueFiles,
 	}
@@ -118,6 +143,21 @@ func NewHelmChartValues(settings *cli.EnvSettings, valueFiles *[]string) (map[st
 		return nil, err
 	}
 
+	for _, override := range overrides {
+		chartValues = mergeValues(chartValues, override)
+	}
+
+	if parameters != nil {
+		parameterValues, err := parameters.MergeValues(getter.All(settings))
+
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+
+		chartValues = mergeValues(chartValues, parameterValues)
+	}
+
 	return chartValues, nil
 
 }
@@ -149,8 +189,29 @@ func NewHelmProvider(releaseName *string, chartPath *string, namespace *string,
 		return nil, err
 	}
 
+	helm.clientSet, err = kubernetes.NewForConfig(restKubeConfig)
+
+	if err != nil {
+		helm.logWithFields().Error(err)
+		return nil, err
+	}
+
+	helm.dynamicClient, err = dynamic.NewForConfig(restKubeConfig)
+
+	if err != nil {
+		helm.logWithFields().Error(err)
+		return nil, err
+	}
+
+	helm.mapper, err = NewResourceMapper(restKubeConfig)
+
+	if err != nil {
+		helm.logWithFields().Error(err)
+		return nil, err
+	}
+
 	helm.appRevision = appRevision
-	helm.ValueFiles = util.GetFilesFullPath(helm.chartPath, &helm.ValueFiles)
+	helm.valueFiles = util.GetFilesFullPath(helm.chartPath, &helm.ValueFiles)
 
 	helm.chart, err = NewHelmChart(helm.manager, helm.chartPath)
 
@@ -181,7 +242,11 @@ func (h *HelmProvider) loadHelmChartWithValues() error {
 		return err
 	}
 
-	h.chartValues, err = NewHelmChartValues(h.settings, &h.ValueFiles)
+	h.statusMutex.Lock()
+	overrides := h.overrides
+	h.statusMutex.Unlock()
+
+	h.chartValues, err = h.getChartValues(overrides)
 
 	if err != nil {
 		h.logWithFields().Error(err)
@@ -209,13 +274,27 @@ func (h *HelmProvider) install() error {
 	install.Atomic = h.ActionOptions.Atomic
 	install.Description = h.appRevision.String()
 
-	_, err := install.Run(h.chart, h.chartValues)
+	postRenderer, err := h.newPostRenderer()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
+	install.PostRenderer = postRenderer
+
+	startedAt := time.Now()
+
+	_, err = install.Run(h.chart, h.chartValues)
 
 	if err != nil {
+		h.addHistory(SyncRecordFailed, fmt.Sprintf("install: %s", err), startedAt)
 		h.logWithFields().Error(err)
 		return err
 	}
 
+	h.addHistory(SyncRecordSucceeded, "installed", startedAt)
+
 	return nil
 }
 
@@ -236,11 +315,31 @@ func (h *HelmProvider) upgrade() error {
 	upgrade.Recreate = false
 	upgrade.Description = h.appRevision.String()
 
+	postRenderer, err := h.newPostRenderer()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
+	upgrade.PostRenderer = postRenderer
+
+	startedAt := time.Now()
+
 	if _, err := upgrade.Run(*h.releaseName, h.chart, h.chartValues); err != nil {
+		// atomic upgrade rolls the release back to the previous release revision
+		if h.ActionOptions.Atomic {
+			h.addHistory(SyncRecordRolledBack, fmt.Sprintf("upgrade: %s", err), startedAt)
+		} else {
+			h.addHistory(SyncRecordFailed, fmt.Sprintf("upgrade: %s", err), startedAt)
+		}
+
 		h.logWithFields().Error(err)
 		return err
 	}
 
+	h.addHistory(SyncRecordSucceeded, "upgraded", startedAt)
+
 	return nil
 }
 
@@ -289,6 +388,11 @@ func (h *HelmProvider) Delivery() error {
 		return err
 	}
 
+	if err := h.loadOverrides(); err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
 	if err := h.loadHelmChartWithValues(); err != nil {
 		h.logWithFields().Error(err)
 		return err
@@ -300,21 +404,61 @@ func (h *HelmProvider) Delivery() error {
 			return err
 		}
 	} else {
-
-		h.chartValues, err = NewHelmChartValues(h.settings, &h.ValueFiles)
-
-		if err != nil {
-			h.logWithFields().Error(err)
-			return err
-		}
-
 		// app revision hash set to Chart.Metadata.Description
 		// for chart control from this application
 		// maybe need to use another field for that
 		if currentRelease.Chart.Metadata.Description == h.appRevision.String() {
-			h.logWithFields().Info(
-				"already exist, skip Delivery",
-			)
+			// values of valuesFrom references change without a new revision
+			valuesEqual, err := isValuesEqual(currentRelease.Config, h.chartValues)
+
+			if err != nil {
+				h.logWithFields().Error(err)
+				return err
+			}
+
+			if !valuesEqual {
+				h.logWithFields().Info("values changed, upgrade release")
+
+				if err := h.upgrade(); err != nil {
+					h.logWithFields().Error(err)
+					return err
+				}
+
+				h.setSync(NewApplicationSync(nil))
+
+				return nil
+			}
+
+			drifts, err := h.getReleaseDrifts(currentRelease)
+
+			if err != nil {
+				h.logWithFields().Error(err)
+				return err
+			}
+
+			h.setSync(NewApplicationSync(drifts))
+
+			if len(drifts) == 0 {
+				h.logWithFields().Info(
+					"already exist, skip Delivery",
+				)
+				return nil
+			}
+
+			if !h.ActionOptions.SelfHeal {
+				h.logWithFields().Infof("drift detected on %d release objects", len(drifts))
+				return nil
+			}
+
+			h.logWithFields().Infof("self-heal drift on %d release objects", len(drifts))
+
+			if err := h.upgrade(); err != nil {
+				h.logWithFields().Error(err)
+				return err
+			}
+
+			h.setSync(NewApplicationSync(nil))
+
 			return nil
 		}
 
@@ -357,5 +501,225 @@ func (h *HelmProvider) Delivery() error {
 
 	h.logWithFields().Infof("delivered")
 
+	h.setSync(NewApplicationSync(nil))
+
 	return nil
 }
+
+// renderResources returns resolved resources of the chart rendered with dry-run install of the current revision
+func (h *HelmProvider) renderResources() ([]*Resource, error) {
+	helmChart, err := loader.Load(*h.chartPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// overrides are read without changing the provider, they are loaded by delivery
+	overrides, err := h.getOverrides()
+
+	if err != nil {
+		return nil, err
+	}
+
+	chartValues, err := h.getChartValues(overrides)
+
+	if err != nil {
+		return nil, err
+	}
+
+	install := action.NewInstall(h.cfg)
+
+	install.ReleaseName = *h.releaseName
+	install.Namespace = *h.namespace
+	install.DryRun = true
+	install.IsUpgrade = true
+	install.Replace = true
+	install.IncludeCRDs = h.ActionOptions.IncludeCRDs
+
+	install.PostRenderer, err = h.newPostRenderer()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := install.Run(helmChart, chartValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := NewManifestResources([]byte(rel.Manifest), *h.chartPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveResources(h.mapper, resources, *h.namespace); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (h *HelmProvider) Render() ([]*unstructured.Unstructured, error) {
+	resources, err := h.renderResources()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	return getResourcesObjects(resources), nil
+}
+
+func (h *HelmProvider) Diff() ([]*ResourceDiff, error) {
+	currentRelease, err := h.getCurrentRelease()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	resources, err := h.renderResources()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	var diffs []*ResourceDiff
+
+	for _, resource := range resources {
+		live, err := getLiveObject(context.Background(), h.dynamicClient, resource)
+
+		if err != nil {
+			h.logWithFields().Error(err)
+			return nil, err
+		}
+
+		if live == nil {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusMissing, "object not found"))
+		} else if currentRelease == nil || currentRelease.Chart.Metadata.Description != h.appRevision.String() {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusOutOfSync, "release of the revision is not installed"))
+		} else if fields := DiffResource(resource.obj, live, h.ignoreRules); len(fields) > 0 {
+			diff := newResourceDiff(resource, SyncStatusOutOfSync, "drifted from the release manifest")
+			diff.Fields = fields
+			diffs = append(diffs, diff)
+		} else {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusSynced, ""))
+		}
+	}
+
+	return diffs, nil
+}
+
+// Health returns health of objects of the installed release manifest
+func (h *HelmProvider) Health() (*ApplicationHealth, error) {
+	currentRelease, err := h.getCurrentRelease()
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	if currentRelease == nil {
+		return &ApplicationHealth{Status: HealthStatusMissing}, nil
+	}
+
+	resources, err := h.getReleaseResources(currentRelease)
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	health, err := getResourcesHealth(context.Background(), h.dynamicClient, resources)
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return nil, err
+	}
+
+	return NewApplicationHealth(health), nil
+}
+
+func (h *HelmProvider) Status() *ApplicationSync {
+	h.statusMutex.Lock()
+	defer h.statusMutex.Unlock()
+
+	if h.sync == nil {
+		return nil
+	}
+
+	status := *h.sync
+	status.History = h.history.list()
+	status.Parameters = h.overrides
+
+	return &status
+}
+
+// setSync replaces sync status of the last delivery, it is read by Status concurrently
+func (h *HelmProvider) setSync(applicationSync *ApplicationSync) {
+	h.statusMutex.Lock()
+	defer h.statusMutex.Unlock()
+
+	h.sync = applicationSync
+}
+
+// addHistory records the release action with the runtime overrides applied by it
+func (h *HelmProvider) addHistory(status SyncRecordStatus, message string, startedAt time.Time) {
+	record := newSyncRecord(h.appRevision.String(), status, message, startedAt)
+
+	h.statusMutex.Lock()
+	record.Parameters = h.overrides
+	h.statusMutex.Unlock()
+
+	h.history.add(record)
+}
+
+// getReleaseResources returns resolved resources of the release manifest
+func (h *HelmProvider) getReleaseResources(rel *release.Release) ([]*Resource, error) {
+	resources, err := NewManifestResources([]byte(rel.Manifest), *h.releaseName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveResources(h.mapper, resources, *h.namespace); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// getReleaseDrifts compares objects of the release manifest with the live ones, deleted objects are reported as missing
+func (h *HelmProvider) getReleaseDrifts(rel *release.Release) ([]*ResourceDiff, error) {
+	resources, err := h.getReleaseResources(rel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []*ResourceDiff
+
+	for _, resource := range resources {
+		live, err := getLiveObject(context.Background(), h.dynamicClient, resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if live == nil {
+			drifts = append(drifts, newResourceDiff(resource, SyncStatusMissing, "object not found"))
+			continue
+		}
+
+		if fields := DiffResource(resource.obj, live, h.ignoreRules); len(fields) > 0 {
+			drift := newResourceDiff(resource, SyncStatusOutOfSync, "drifted from the release manifest")
+			drift.Fields = fields
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts, nil
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/client-go/dynamic"
+	"net/http"
+	"testing"
+)
+
+// newTestLiveServer serves the live objects by api path, other objects are not found
+func newTestLiveServer(t *testing.T, objects map[string]string) *dynamic.DynamicClient {
+	t.Helper()
+
+	return newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if object, exist := objects[req.URL.Path]; exist && req.Method == http.MethodGet {
+			_, _ = w.Write([]byte(object))
+			return
+		}
+
+		writeTestStatus(w, http.StatusNotFound, "NotFound")
+	})
+}
+
+func TestGetReleaseDrifts(t *testing.T) {
+	releaseName := "web"
+	namespace := "default"
+	mapper, _ := newTestResourceMapper(testAPIResources)
+
+	rules, err := NewIgnoreRules([]IgnoreDifference{{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &HelmProvider{
+		releaseName: &releaseName,
+		namespace:   &namespace,
+		mapper:      mapper,
+		ignoreRules: rules,
+		dynamicClient: newTestLiveServer(t, map[string]string{
+			"/api/v1/namespaces/default/configmaps/synced": `{"apiVersion": "v1", "kind": "ConfigMap", ` +
+				`"metadata": {"name": "synced", "namespace": "default", "uid": "a"}, "data": {"key": "a"}}`,
+			"/api/v1/namespaces/default/configmaps/drifted": `{"apiVersion": "v1", "kind": "ConfigMap", ` +
+				`"metadata": {"name": "drifted", "namespace": "default"}, "data": {"key": "changed"}}`,
+			"/apis/apps/v1/namespaces/other/deployments/scaled": `{"apiVersion": "apps/v1", "kind": "Deployment", ` +
+				`"metadata": {"name": "scaled", "namespace": "other"}, "spec": {"replicas": 5}}`,
+		}),
+	}
+
+	rel := &release.Release{Manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: synced}\ndata: {key: a}\n---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata: {name: drifted}\ndata: {key: a}\n---\n" +
+		"apiVersion: v1\nkind: ConfigMap\nmetadata: {name: deleted}\n---\n" +
+		"apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: scaled, namespace: other}\nspec: {replicas: 1}\n"}
+
+	drifts, err := h.getReleaseDrifts(rel)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for _, drift := range drifts {
+		got = append(got, fmt.Sprintf("%s %s %v", drift.Name, drift.Status, drift.Fields))
+	}
+
+	want := []string{"drifted OutOfSync [.data.key]", "deleted Missing []"}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("getReleaseDrifts() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"flag"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var historyLimit = flag.Int("history-limit", 10, "number of sync records kept per application")
+
+// newSyncRecord returns the record of the sync of the revision started at the time
+func newSyncRecord(revision string, status SyncRecordStatus, message string, startedAt time.Time) *SyncRecord {
+	return &SyncRecord{
+		Revision:   revision,
+		Status:     status,
+		Message:    message,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+	}
+}
+
+// syncHistory holds the latest sync records limited with history-limit flag
+type syncHistory struct {
+	mutex   sync.Mutex
+	records []*SyncRecord
+}
+
+// add appends the record, retries of the revision with the same result replace the last record
+func (h *syncHistory) add(record *SyncRecord) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if last := len(h.records) - 1; last >= 0 && isSameSync(h.records[last], record) {
+		h.records[last] = record
+		return
+	}
+
+	h.records = append(h.records, record)
+
+	if len(h.records) > *historyLimit {
+		h.records = h.records[len(h.records)-*historyLimit:]
+	}
+}
+
+// list returns copy of the records, the latest is the last
+func (h *syncHistory) list() []*SyncRecord {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([]*SyncRecord{}, h.records...)
+}
+
+func isSameSync(a *SyncRecord, b *SyncRecord) bool {
+	return a.Revision == b.Revision && a.Status == b.Status && reflect.DeepEqual(a.Parameters, b.Parameters)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSyncHistoryAdd(t *testing.T) {
+	limit := *historyLimit
+	*historyLimit = 3
+	defer func() { *historyLimit = limit }()
+
+	tests := []struct {
+		name    string
+		records []*SyncRecord
+		want    []string
+	}{
+		{
+			name: "retry of the revision replaces the record",
+			records: []*SyncRecord{
+				{Revision: "a", Status: SyncRecordSucceeded},
+				{Revision: "b", Status: SyncRecordFailed, Message: "first"},
+				{Revision: "b", Status: SyncRecordFailed, Message: "second"},
+			},
+			want: []string{"a Succeeded ", "b Failed second"},
+		},
+		{
+			name: "other result of the revision is appended",
+			records: []*SyncRecord{
+				{Revision: "a", Status: SyncRecordFailed},
+				{Revision: "a", Status: SyncRecordRolledBack},
+			},
+			want: []string{"a Failed ", "a RolledBack "},
+		},
+		{
+			name: "changed parameters are appended",
+			records: []*SyncRecord{
+				{Revision: "a", Status: SyncRecordSucceeded},
+				{Revision: "a", Status: SyncRecordSucceeded, Parameters: []HelmParameter{{Name: "replicas", Value: "2"}}},
+			},
+			want: []string{"a Succeeded ", "a Succeeded "},
+		},
+		{
+			name: "limit keeps the latest records",
+			records: []*SyncRecord{
+				{Revision: "a", Status: SyncRecordSucceeded},
+				{Revision: "b", Status: SyncRecordSucceeded},
+				{Revision: "c", Status: SyncRecordSucceeded},
+				{Revision: "d", Status: SyncRecordSucceeded},
+			},
+			want: []string{"b Succeeded ", "c Succeeded ", "d Succeeded "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var history syncHistory
+
+			for _, record := range tt.records {
+				history.add(record)
+			}
+
+			var got []string
+
+			for _, record := range history.list() {
+				got = append(got, fmt.Sprintf("%s %s %s", record.Revision, record.Status, record.Message))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("history = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HookAnnotation marks the object as a hook of comma-separated sync phases, hooks are not applied with other resources
+	HookAnnotation = "dummy.cd/hook"
+	// HookDeletePolicyAnnotation is comma-separated policies to delete the hook after it is finished
+	HookDeletePolicyAnnotation = "dummy.cd/hook-delete-policy"
+
+	// hookPhaseAnnotation records the phase the hook was created for, so hooks of several phases run in each of them
+	hookPhaseAnnotation = "dummy.cd/hook-phase"
+
+	HookPreSync  = "PreSync"
+	HookPostSync = "PostSync"
+	HookSyncFail = "SyncFail"
+
+	// HookBeforeHookCreation is the default policy, the hook left from the previous run is deleted before it is created again
+	HookBeforeHookCreation = "BeforeHookCreation"
+	HookSucceeded          = "HookSucceeded"
+	HookFailed             = "HookFailed"
+)
+
+var hookTimeout = flag.Duration("hook-timeout", 10*time.Minute, "timeout to wait for a sync hook to complete")
+
+// getAnnotationValues returns trimmed comma-separated values of the object annotation
+func getAnnotationValues(obj *unstructured.Unstructured, annotation string) []string {
+	var values []string
+
+	for _, value := range strings.Split(obj.GetAnnotations()[annotation], ",") {
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// IsHook reports whether the object is a sync hook
+func IsHook(obj *unstructured.Unstructured) bool {
+	return len(getAnnotationValues(obj, HookAnnotation)) > 0
+}
+
+// splitHooks returns resources applied on delivery and hooks separately
+func splitHooks(resources []*Resource) ([]*Resource, []*Resource) {
+	var regular []*Resource
+	var hooks []*Resource
+
+	for _, resource := range resources {
+		if IsHook(resource.obj) {
+			hooks = append(hooks, resource)
+		} else {
+			regular = append(regular, resource)
+		}
+	}
+
+	return regular, hooks
+}
+
+// isHookFinished reports whether the hook completed, Pods have to succeed, Jobs to complete and other objects to be healthy
+func isHookFinished(ctx context.Context, dynamicClient dynamic.Interface, live *unstructured.Unstructured) (bool, error) {
+	if live == nil {
+		return false, nil
+	}
+
+	if live.GroupVersionKind().Group == "" && live.GetKind() == "Pod" {
+		phase, _, _ := unstructured.NestedString(live.Object, "status", "phase")
+
+		switch phase {
+		case "Succeeded":
+			return true, nil
+		case "Failed":
+			message, _, _ := unstructured.NestedString(live.Object, "status", "message")
+			return false, fmt.Errorf("%w: pod failed: %s", util.ErrHookFailed, message)
+		}
+
+		return false, nil
+	}
+
+	status, message, err := GetResourceHealth(ctx, dynamicClient, live)
+
+	if err != nil {
+		return false, err
+	}
+
+	if status == HealthStatusDegraded {
+		return false, fmt.Errorf("%w: %s", util.ErrHookFailed, message)
+	}
+
+	return status == HealthStatusHealthy, nil
+}
+
+// runHooks runs hooks of the phase in sync wave and kind order, hooks of a step run concurrently
+// and the next step starts when all of them are finished, the first failed step stops the phase
+func (r *RawProvider) runHooks(hooks []*Resource, phase string) ([]*ResourceResult, error) {
+	var phaseHooks []*Resource
+
+	for _, hook := range hooks {
+		if util.ContainsString(getAnnotationValues(hook.obj, HookAnnotation), phase) {
+			phaseHooks = append(phaseHooks, hook)
+		}
+	}
+
+	if len(phaseHooks) == 0 {
+		return nil, nil
+	}
+
+	r.logWithFields().Infof("running %d %s hooks", len(phaseHooks), phase)
+
+	var results []*ResourceResult
+
+	for _, step := range newSyncSteps(phaseHooks, false) {
+		var wg sync.WaitGroup
+
+		stepResults := make([]*ResourceResult, len(step.resources))
+
+		for i, hook := range step.resources {
+			wg.Add(1)
+			go func(i int, hook *Resource) {
+				defer wg.Done()
+				stepResults[i] = r.runHook(hook, phase)
+			}(i, hook)
+		}
+
+		wg.Wait()
+
+		results = append(results, stepResults...)
+
+		var errs []error
+
+		for _, result := range stepResults {
+			if result.Err != nil {
+				errs = append(errs, result.Err)
+			}
+		}
+
+		if len(errs) > 0 {
+			return results, fmt.Errorf("%s hooks: %w", phase, errors.Join(errs...))
+		}
+	}
+
+	return results, nil
+}
+
+// runHook creates the hook, waits for it to finish and applies its delete policy,
+// the hook finished for the current revision is not run again
+func (r *RawProvider) runHook(hook *Resource, phase string) *ResourceResult {
+	if err := hook.resolve(r.mapper, *r.namespace); err != nil {
+		return r.failResource(hook, err)
+	}
+
+	if err := r.checkAllowed(hook.obj.GroupVersionKind().GroupKind(), hook.namespaced, hook.namespace); err != nil {
+		return r.failResource(hook, err)
+	}
+
+	setTrackingLabels(hook.obj, r.labels)
+
+	annotations := hook.obj.GetAnnotations()
+	annotations[hookPhaseAnnotation] = phase
+	hook.obj.SetAnnotations(annotations)
+
+	live, err := getLiveObject(r.ctx, r.dynamicClient, hook)
+
+	if err != nil {
+		return r.failResource(hook, err)
+	}
+
+	if live != nil {
+		if err := r.checkOwner(live); err != nil {
+			return r.failResource(hook, err)
+		}
+
+		if live.GetLabels()["dummy.cd/revision"] == r.appRevision.String() && live.GetAnnotations()[hookPhaseAnnotation] == phase {
+			if finished, _ := isHookFinished(r.ctx, r.dynamicClient, live); finished {
+				return newResourceResult(hook, ResourceOperationUnchanged, fmt.Sprintf("%s hook already completed", phase))
+			}
+		}
+
+		if err := r.deleteHook(hook, true); err != nil {
+			return r.failResource(hook, err)
+		}
+	}
+
+	_, err = r.resourceInterface(hook).Apply(r.ctx, hook.obj.GetName(), hook.obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+	})
+
+	if err != nil {
+		return r.failResource(hook, err)
+	}
+
+	if err := r.updateInventory([]*Resource{hook}, nil); err != nil {
+		r.logWithFields().Errorf("inventory: %s", err)
+	}
+
+	err = waitResources(r.ctx, r.dynamicClient, []*Resource{hook}, *hookTimeout, func(ctx context.Context, live *unstructured.Unstructured) (bool, error) {
+		return isHookFinished(ctx, r.dynamicClient, live)
+	})
+
+	policies := getAnnotationValues(hook.obj, HookDeletePolicyAnnotation)
+
+	if (err == nil && util.ContainsString(policies, HookSucceeded)) || (err != nil && util.ContainsString(policies, HookFailed)) {
+		if deleteErr := r.deleteHook(hook, false); deleteErr != nil {
+			r.logWithFields().Errorf("%s: %+v", deleteErr, hook)
+		}
+	}
+
+	if err != nil {
+		return r.failResource(hook, fmt.Errorf("%s hook: %w", phase, err))
+	}
+
+	r.logWithFields().Infof("%s hook completed %+v", phase, hook)
+
+	return newResourceResult(hook, ResourceOperationCreated, fmt.Sprintf("%s hook completed", phase))
+}
+
+// deleteHook deletes the hook with its dependents like Pods of Jobs, wait is used before the hook is created again
+func (r *RawProvider) deleteHook(hook *Resource, wait bool) error {
+	propagationPolicy := metav1.DeletePropagationForeground
+
+	err := r.resourceInterface(hook).Delete(r.ctx, hook.obj.GetName(), metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil || !wait {
+		return err
+	}
+
+	return waitDeleted(r.ctx, r.dynamicClient, []*Resource{hook})
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestGetAnnotationValues(t *testing.T) {
+	obj := newTestObject(t, "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a, annotations: {dummy.cd/hook: ' PreSync, ,PostSync '}}\n")
+
+	if got := getAnnotationValues(obj, HookAnnotation); fmt.Sprint(got) != "[PreSync PostSync]" {
+		t.Errorf("getAnnotationValues() = %v, want [PreSync PostSync]", got)
+	}
+
+	if got := getAnnotationValues(obj, HookDeletePolicyAnnotation); got != nil {
+		t.Errorf("getAnnotationValues() of missing annotation = %v, want nil", got)
+	}
+}
+
+func TestSplitHooks(t *testing.T) {
+	resources := []*Resource{
+		{obj: newTestObject(t, "apiVersion: batch/v1\nkind: Job\nmetadata: {name: migrate, annotations: {dummy.cd/hook: PreSync}}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: config}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: empty, annotations: {dummy.cd/hook: ''}}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: Pod\nmetadata: {name: notify, annotations: {dummy.cd/hook: 'PostSync,SyncFail'}}\n")},
+	}
+
+	regular, hooks := splitHooks(resources)
+
+	if got := getResourcesNames(regular); fmt.Sprint(got) != "[config empty]" {
+		t.Errorf("splitHooks() regular = %v, want [config empty]", got)
+	}
+
+	if got := getResourcesNames(hooks); fmt.Sprint(got) != "[migrate notify]" {
+		t.Errorf("splitHooks() hooks = %v, want [migrate notify]", got)
+	}
+}
+
+func getResourcesNames(resources []*Resource) []string {
+	var names []string
+
+	for _, resource := range resources {
+		names = append(names, resource.obj.GetName())
+	}
+
+	return names
+}
+
+func TestIsHookFinished(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     bool
+		wantErr  error
+	}{
+		{
+			name:     "pod running",
+			manifest: "apiVersion: v1\nkind: Pod\nmetadata: {name: a}\nstatus: {phase: Running, conditions: [{type: Ready, status: 'True'}]}\n",
+		},
+		{
+			name:     "pod succeeded",
+			manifest: "apiVersion: v1\nkind: Pod\nmetadata: {name: a}\nstatus: {phase: Succeeded}\n",
+			want:     true,
+		},
+		{
+			name:     "pod failed",
+			manifest: "apiVersion: v1\nkind: Pod\nmetadata: {name: a}\nstatus: {phase: Failed, message: oom}\n",
+			wantErr:  util.ErrHookFailed,
+		},
+		{
+			name:     "job running",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {active: 1}\n",
+		},
+		{
+			name:     "job complete",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {conditions: [{type: Complete, status: 'True'}]}\n",
+			want:     true,
+		},
+		{
+			name:     "job failed",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {conditions: [{type: Failed, status: 'True', message: backoff}]}\n",
+			wantErr:  util.ErrHookFailed,
+		},
+		{
+			name:     "config map",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isHookFinished(context.Background(), nil, newTestObject(t, tt.manifest))
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("isHookFinished() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("isHookFinished() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if finished, err := isHookFinished(context.Background(), nil, nil); finished || err != nil {
+		t.Errorf("isHookFinished() of deleted hook = %v, %v", finished, err)
+	}
+}
+
+func TestDeliveryHooksAfterRestart(t *testing.T) {
+	configMaps := make(map[string]*corev1.ConfigMap)
+	clientSet := newTestConfigMapClientSet(t, configMaps)
+
+	var mutex sync.Mutex
+	var hook []byte
+	var applies int
+
+	dynamicClient := newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch req.Method {
+		case http.MethodPatch:
+			applies++
+
+			obj := &unstructured.Unstructured{}
+
+			if err := json.NewDecoder(req.Body).Decode(&obj.Object); err != nil {
+				t.Error(err)
+			}
+
+			_ = unstructured.SetNestedField(obj.Object, "Succeeded", "status", "phase")
+			hook, _ = obj.MarshalJSON()
+		case http.MethodDelete:
+			hook = nil
+			_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "Status", "status": "Success"}`))
+
+			return
+		}
+
+		if hook == nil {
+			writeTestStatus(w, http.StatusNotFound, "NotFound")
+			return
+		}
+
+		_, _ = w.Write(hook)
+	})
+
+	mapper, _ := newTestResourceMapper([]*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}},
+		},
+	})
+
+	// every delivery is done by a new provider, as after restart of the server
+	for i := 0; i < 2; i++ {
+		r := newTestRawProvider(RawActionOptions{})
+		r.mapper = mapper
+		r.mutex = new(sync.Mutex)
+		r.inventoryMutex = new(sync.Mutex)
+		r.clientSet = clientSet
+		r.dynamicClient = dynamicClient
+		r.labels = map[string]string{"dummy.cd/app": "app"}
+		r.resources = []*Resource{
+			{obj: newTestObject(t, "apiVersion: v1\nkind: Pod\nmetadata: {name: migrate, annotations: {dummy.cd/hook: PreSync, dummy.cd/hook-delete-policy: HookSucceeded}}\n")},
+		}
+
+		if err := r.Delivery(); err != nil {
+			t.Fatal(err)
+		}
+
+		// waits for the cleanup started by the delivery
+		r.mutex.Lock()
+		r.mutex.Unlock()
+	}
+
+	if applies != 1 {
+		t.Errorf("Delivery() applied the hook %d times, want 1", applies)
+	}
+
+	if got := configMaps["dummycd-inventory-app"].GetAnnotations()[inventoryHookRevisionKey]; got != plumbing.ZeroHash.String() {
+		t.Errorf("Delivery() hook revision = %s, want %s", got, plumbing.ZeroHash)
+	}
+}
@@ -0,0 +1,706 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"os"
+	"reflect"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ignoreDifferencesFile = flag.String("ignore-differences-file", "", "yaml file with ignoreDifferences rules for all applications")
+
+	globalIgnoreRules     []*ignoreRule
+	globalIgnoreRulesOnce = new(sync.Once)
+
+	// listMergeKeys are merge keys of lists in the Kubernetes schema, e.g. containers, env and volumes by name,
+	// volume mounts by mount path and ports by port number
+	listMergeKeys = []string{"name", "mountPath", "devicePath", "containerPort", "port"}
+)
+
+// IgnoreDifference excludes fields of the matched objects from diff, drift detection and self-heal,
+// empty Name and Namespace match any object, "*" matches any Group or Kind
+type IgnoreDifference struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// JSONPointers are RFC 6901 paths, e.g. /spec/replicas
+	JSONPointers []string `json:"jsonPointers"`
+	// JQPathExpressions are jq-style paths, e.g. .spec.template.spec.containers[] | select(.name == "istio-proxy")
+	JQPathExpressions []string `json:"jqPathExpressions"`
+	// ManagedFieldsManagers ignore fields owned by the field managers, e.g. kube-controller-manager
+	ManagedFieldsManagers []string `json:"managedFieldsManagers"`
+}
+
+type ignoreRule struct {
+	IgnoreDifference
+	paths [][]pathElement
+}
+
+// pathElement is a field path step, list steps select items by index, by fields, by value or all of them
+type pathElement struct {
+	key      *string
+	index    *int
+	all      bool
+	match    map[string]interface{}
+	value    interface{}
+	hasValue bool
+}
+
+// NewIgnoreRules returns compiled rules, invalid paths are returned as error
+func NewIgnoreRules(differences []IgnoreDifference) ([]*ignoreRule, error) {
+	var rules []*ignoreRule
+
+	for _, difference := range differences {
+		rule := &ignoreRule{IgnoreDifference: difference}
+
+		for _, pointer := range difference.JSONPointers {
+			path, err := parseJSONPointer(pointer)
+
+			if err != nil {
+				return nil, err
+			}
+
+			rule.paths = append(rule.paths, path)
+		}
+
+		for _, expression := range difference.JQPathExpressions {
+			path, err := parseJQPath(expression)
+
+			if err != nil {
+				return nil, err
+			}
+
+			rule.paths = append(rule.paths, path)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// getGlobalIgnoreRules returns rules of the ignore-differences-file, the file is read once
+func getGlobalIgnoreRules() []*ignoreRule {
+	globalIgnoreRulesOnce.Do(func() {
+		if len(*ignoreDifferencesFile) == 0 {
+			return
+		}
+
+		data, err := os.ReadFile(*ignoreDifferencesFile)
+
+		if err != nil {
+			log.Errorf("%s: %s", *ignoreDifferencesFile, err)
+			return
+		}
+
+		var differences []IgnoreDifference
+
+		if err := yaml.Unmarshal(data, &differences); err != nil {
+			log.Errorf("%s: %s", *ignoreDifferencesFile, err)
+			return
+		}
+
+		globalIgnoreRules, err = NewIgnoreRules(differences)
+
+		if err != nil {
+			log.Errorf("%s: %s", *ignoreDifferencesFile, err)
+		}
+	})
+
+	return globalIgnoreRules
+}
+
+func withGlobalIgnoreRules(rules []*ignoreRule) []*ignoreRule {
+	return append(append([]*ignoreRule{}, getGlobalIgnoreRules()...), rules...)
+}
+
+func (r *ignoreRule) matches(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+
+	return (r.Group == "*" || r.Group == gvk.Group) &&
+		(r.Kind == "*" || r.Kind == gvk.Kind) &&
+		(len(r.Name) == 0 || r.Name == obj.GetName()) &&
+		(len(r.Namespace) == 0 || r.Namespace == obj.GetNamespace())
+}
+
+// getIgnoredPaths returns paths of the rules matched by the object, fields of the rule managers are taken from the live object
+func getIgnoredPaths(rules []*ignoreRule, desired *unstructured.Unstructured, live *unstructured.Unstructured) [][]pathElement {
+	var paths [][]pathElement
+
+	for _, rule := range rules {
+		if !rule.matches(desired) {
+			continue
+		}
+
+		paths = append(paths, rule.paths...)
+
+		if len(rule.ManagedFieldsManagers) > 0 && live != nil {
+			paths = append(paths, getManagedFieldsPaths(live, rule.ManagedFieldsManagers)...)
+		}
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) < len(paths[j])
+	})
+
+	return paths
+}
+
+// getManagedFieldsPaths returns paths of the fields owned by the managers
+func getManagedFieldsPaths(live *unstructured.Unstructured, managers []string) [][]pathElement {
+	var paths [][]pathElement
+
+	for _, entry := range live.GetManagedFields() {
+		if !util.ContainsString(managers, entry.Manager) || entry.FieldsV1 == nil {
+			continue
+		}
+
+		var set fieldpath.Set
+
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			log.Debugf("managed fields of %s: %s", entry.Manager, err)
+			continue
+		}
+
+		set.Iterate(func(p fieldpath.Path) {
+			var path []pathElement
+
+			for _, element := range p {
+				switch {
+				case element.FieldName != nil:
+					path = append(path, pathElement{key: element.FieldName})
+				case element.Index != nil:
+					path = append(path, pathElement{index: element.Index})
+				case element.Key != nil:
+					match := make(map[string]interface{})
+
+					for _, field := range *element.Key {
+						match[field.Name] = field.Value.Unstructured()
+					}
+
+					path = append(path, pathElement{match: match})
+				case element.Value != nil:
+					path = append(path, pathElement{value: (*element.Value).Unstructured(), hasValue: true})
+				}
+			}
+
+			paths = append(paths, path)
+		})
+	}
+
+	return paths
+}
+
+// DiffResource returns drifted fields of the live object, desired write-only fields are converted to the stored form
+// and fields ignored by the rules or the global rules are removed from both objects
+func DiffResource(desired *unstructured.Unstructured, live *unstructured.Unstructured, rules []*ignoreRule) []string {
+	desired = normalizeDesired(desired).DeepCopy()
+	live = live.DeepCopy()
+
+	for _, path := range getIgnoredPaths(withGlobalIgnoreRules(rules), desired, live) {
+		removeFields(desired.Object, path)
+		removeFields(live.Object, path)
+	}
+
+	return DiffObject(desired.Object, live.Object)
+}
+
+// RespectIgnoredFields returns copy of the desired object with ignored fields set to the live values,
+// so self-heal does not revert them, ignored fields absent in the live object are not applied
+func RespectIgnoredFields(desired *unstructured.Unstructured, live *unstructured.Unstructured, rules []*ignoreRule) *unstructured.Unstructured {
+	result := desired.DeepCopy()
+
+	for _, path := range getIgnoredPaths(withGlobalIgnoreRules(rules), desired, live) {
+		concretePaths := expandPath(result.Object, path, nil)
+
+		sortConcretePathsDesc(concretePaths)
+
+		for _, concretePath := range concretePaths {
+			if value, exist := getLivePath(result.Object, live.Object, concretePath); exist {
+				setConcretePath(result.Object, concretePath, value)
+			} else {
+				removeConcretePath(result.Object, concretePath)
+			}
+		}
+	}
+
+	return result
+}
+
+func removeFields(obj map[string]interface{}, path []pathElement) {
+	concretePaths := expandPath(obj, path, nil)
+
+	sortConcretePathsDesc(concretePaths)
+
+	for _, concretePath := range concretePaths {
+		removeConcretePath(obj, concretePath)
+	}
+}
+
+// expandPath returns paths of map keys and list indexes matched by the path in the node
+func expandPath(node interface{}, path []pathElement, prefix []interface{}) [][]interface{} {
+	if len(path) == 0 {
+		return [][]interface{}{append([]interface{}{}, prefix...)}
+	}
+
+	element := path[0]
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if element.key == nil {
+			return nil
+		}
+
+		child, exist := n[*element.key]
+
+		if !exist {
+			return nil
+		}
+
+		return expandPath(child, path[1:], append(prefix, *element.key))
+	case []interface{}:
+		var concretePaths [][]interface{}
+
+		for i, item := range n {
+			if element.matchItem(i, item) {
+				concretePaths = append(concretePaths, expandPath(item, path[1:], append(append([]interface{}{}, prefix...), i))...)
+			}
+		}
+
+		return concretePaths
+	}
+
+	return nil
+}
+
+func (e *pathElement) matchItem(i int, item interface{}) bool {
+	switch {
+	case e.all:
+		return true
+	case e.index != nil:
+		return *e.index == i
+	case e.key != nil:
+		index, err := strconv.Atoi(*e.key)
+		return err == nil && index == i
+	case e.hasValue:
+		return equalValue(e.value, item)
+	case e.match != nil:
+		fields, ok := item.(map[string]interface{})
+
+		if !ok {
+			return false
+		}
+
+		for name, value := range e.match {
+			if !equalValue(value, fields[name]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func equalValue(a interface{}, b interface{}) bool {
+	aNumber, aIsNumber := toFloat(a)
+	bNumber, bIsNumber := toFloat(b)
+
+	if aIsNumber && bIsNumber {
+		return aNumber == bNumber
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// sortConcretePathsDesc orders paths so list items with greater indexes are removed first
+func sortConcretePathsDesc(paths [][]interface{}) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		for k := 0; k < len(paths[i]) && k < len(paths[j]); k++ {
+			a, aIsIndex := paths[i][k].(int)
+			b, bIsIndex := paths[j][k].(int)
+
+			if aIsIndex && bIsIndex && a != b {
+				return a > b
+			}
+		}
+
+		return false
+	})
+}
+
+func getConcretePath(node interface{}, path []interface{}) (interface{}, bool) {
+	for _, step := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			key, ok := step.(string)
+
+			if !ok {
+				return nil, false
+			}
+
+			child, exist := n[key]
+
+			if !exist {
+				return nil, false
+			}
+
+			node = child
+		case []interface{}:
+			index, ok := step.(int)
+
+			if !ok || index >= len(n) {
+				return nil, false
+			}
+
+			node = n[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// getLivePath returns the live value at the concrete path of the desired object, list items are matched by their merge key,
+// so items of reordered lists are not mixed, items without merge key are matched at the same index
+func getLivePath(desired interface{}, live interface{}, path []interface{}) (interface{}, bool) {
+	for _, step := range path {
+		switch d := desired.(type) {
+		case map[string]interface{}:
+			key, ok := step.(string)
+			l, isMap := live.(map[string]interface{})
+
+			if !ok || !isMap {
+				return nil, false
+			}
+
+			child, exist := l[key]
+
+			if !exist {
+				return nil, false
+			}
+
+			desired, live = d[key], child
+		case []interface{}:
+			index, ok := step.(int)
+			l, isList := live.([]interface{})
+
+			if !ok || !isList || index >= len(d) {
+				return nil, false
+			}
+
+			liveIndex, found := matchListItem(d[index], index, l)
+
+			if !found {
+				return nil, false
+			}
+
+			desired, live = d[index], l[liveIndex]
+		default:
+			return nil, false
+		}
+	}
+
+	return live, true
+}
+
+// matchListItem returns index of the live item with the same merge key as the desired item, the first key of listMergeKeys
+// set in the desired item is used, items without them are matched at the same index
+func matchListItem(item interface{}, index int, live []interface{}) (int, bool) {
+	if fields, ok := item.(map[string]interface{}); ok {
+		for _, mergeKey := range listMergeKeys {
+			value, exist := fields[mergeKey]
+
+			if !exist {
+				continue
+			}
+
+			for i, liveItem := range live {
+				if liveFields, ok := liveItem.(map[string]interface{}); ok && equalValue(value, liveFields[mergeKey]) {
+					return i, true
+				}
+			}
+
+			return 0, false
+		}
+	}
+
+	return index, index < len(live)
+}
+
+func setConcretePath(node interface{}, path []interface{}, value interface{}) {
+	if len(path) == 0 {
+		return
+	}
+
+	parent, exist := getConcretePath(node, path[:len(path)-1])
+
+	if !exist {
+		return
+	}
+
+	switch p := parent.(type) {
+	case map[string]interface{}:
+		if key, ok := path[len(path)-1].(string); ok {
+			p[key] = value
+		}
+	case []interface{}:
+		if index, ok := path[len(path)-1].(int); ok && index < len(p) {
+			p[index] = value
+		}
+	}
+}
+
+func removeConcretePath(node interface{}, path []interface{}) interface{} {
+	if len(path) == 0 {
+		return node
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		key, ok := path[0].(string)
+
+		if !ok {
+			return node
+		}
+
+		child, exist := n[key]
+
+		if !exist {
+			return node
+		}
+
+		if len(path) == 1 {
+			delete(n, key)
+		} else {
+			n[key] = removeConcretePath(child, path[1:])
+		}
+	case []interface{}:
+		index, ok := path[0].(int)
+
+		if !ok || index >= len(n) {
+			return node
+		}
+
+		if len(path) == 1 {
+			return append(append([]interface{}{}, n[:index]...), n[index+1:]...)
+		}
+
+		n[index] = removeConcretePath(n[index], path[1:])
+	}
+
+	return node
+}
+
+// parseJSONPointer parses RFC 6901 pointer, numeric steps select list items by index
+func parseJSONPointer(pointer string) ([]pathElement, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer %q", pointer)
+	}
+
+	var path []pathElement
+
+	for _, step := range strings.Split(pointer[1:], "/") {
+		key := strings.ReplaceAll(strings.ReplaceAll(step, "~1", "/"), "~0", "~")
+		path = append(path, pathElement{key: &key})
+	}
+
+	return path, nil
+}
+
+// parseJQPath parses jq-style path: .field, ["field"], [index], [] and select(.field == value) of list items joined with pipes
+func parseJQPath(expression string) ([]pathElement, error) {
+	var path []pathElement
+
+	for _, part := range splitJQPipes(expression) {
+		part = strings.TrimSpace(part)
+
+		if strings.HasPrefix(part, "select(") && strings.HasSuffix(part, ")") {
+			if len(path) == 0 || !path[len(path)-1].all {
+				return nil, fmt.Errorf("invalid jq path %q: select is supported only for list items []", expression)
+			}
+
+			match, err := parseJQSelect(strings.TrimSuffix(strings.TrimPrefix(part, "select("), ")"))
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid jq path %q: %w", expression, err)
+			}
+
+			path[len(path)-1] = pathElement{match: match}
+			continue
+		}
+
+		elements, err := parseJQSteps(part)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid jq path %q: %w", expression, err)
+		}
+
+		path = append(path, elements...)
+	}
+
+	if len(path) == 0 {
+		return nil, fmt.Errorf("invalid jq path %q", expression)
+	}
+
+	return path, nil
+}
+
+func splitJQPipes(expression string) []string {
+	var parts []string
+	var quoted bool
+
+	start := 0
+
+	for i := 0; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case '|':
+			if !quoted {
+				parts = append(parts, expression[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, expression[start:])
+}
+
+// parseJQSteps parses path steps, "." alone is the identity
+func parseJQSteps(part string) ([]pathElement, error) {
+	var path []pathElement
+
+	for i := 0; i < len(part); {
+		switch {
+		case part[i] == '.' && i+1 < len(part) && part[i+1] != '[':
+			end := i + 1
+
+			for end < len(part) && part[end] != '.' && part[end] != '[' {
+				end++
+			}
+
+			key := part[i+1 : end]
+
+			if !fieldNameRegex.MatchString(key) {
+				return nil, fmt.Errorf("invalid field %q", key)
+			}
+
+			path = append(path, pathElement{key: &key})
+			i = end
+		case part[i] == '.':
+			i++
+		case part[i] == '[':
+			end := strings.Index(part[i:], "]")
+
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed bracket")
+			}
+
+			step := part[i+1 : i+end]
+
+			if strings.HasPrefix(step, "\"") {
+				end = closingQuotedBracket(part, i)
+
+				if end == -1 {
+					return nil, fmt.Errorf("unclosed bracket")
+				}
+
+				key, err := strconv.Unquote(part[i+1 : end])
+
+				if err != nil {
+					return nil, err
+				}
+
+				path = append(path, pathElement{key: &key})
+				i = end + 1
+				continue
+			}
+
+			if len(step) == 0 {
+				path = append(path, pathElement{all: true})
+			} else {
+				index, err := strconv.Atoi(step)
+
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid index %q", step)
+				}
+
+				path = append(path, pathElement{index: &index})
+			}
+
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q", part[i:])
+		}
+	}
+
+	return path, nil
+}
+
+// closingQuotedBracket returns position of "]" closing the quoted key which starts at open bracket
+func closingQuotedBracket(part string, open int) int {
+	for i := open + 2; i < len(part); i++ {
+		switch part[i] {
+		case '\\':
+			i++
+		case '"':
+			if i+1 < len(part) && part[i+1] == ']' {
+				return i + 1
+			}
+
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// parseJQSelect parses conditions as .field == value joined with and, value is json literal
+func parseJQSelect(condition string) (map[string]interface{}, error) {
+	match := make(map[string]interface{})
+
+	for _, part := range strings.Split(condition, " and ") {
+		operands := strings.SplitN(part, "==", 2)
+
+		if len(operands) != 2 {
+			return nil, fmt.Errorf("unsupported select condition %q", part)
+		}
+
+		field := strings.TrimPrefix(strings.TrimSpace(operands[0]), ".")
+
+		if !fieldNameRegex.MatchString(field) {
+			return nil, fmt.Errorf("unsupported select field %q", field)
+		}
+
+		var value interface{}
+
+		decoder := json.NewDecoder(strings.NewReader(strings.TrimSpace(operands[1])))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("select value of %s: %w", field, err)
+		}
+
+		match[field] = value
+	}
+
+	return match, nil
+}
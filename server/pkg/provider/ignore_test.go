@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// formatPath returns readable form of the parsed path, list items matched by fields are shown as [field=value]
+func formatPath(path []pathElement) string {
+	var b strings.Builder
+
+	for _, element := range path {
+		switch {
+		case element.key != nil:
+			b.WriteString("." + *element.key)
+		case element.index != nil:
+			fmt.Fprintf(&b, "[%d]", *element.index)
+		case element.all:
+			b.WriteString("[]")
+		case element.match != nil:
+			var fields []string
+
+			for name, value := range element.match {
+				fields = append(fields, fmt.Sprintf("%s=%v", name, value))
+			}
+
+			sort.Strings(fields)
+			b.WriteString("[" + strings.Join(fields, ",") + "]")
+		}
+	}
+
+	return b.String()
+}
+
+func TestParseJSONPointer(t *testing.T) {
+	tests := []struct {
+		pointer string
+		want    string
+		wantErr bool
+	}{
+		{pointer: "/spec/replicas", want: ".spec.replicas"},
+		{pointer: "/metadata/annotations/example.com~1name", want: ".metadata.annotations.example.com/name"},
+		{pointer: "/data/a~0b", want: ".data.a~b"},
+		{pointer: "/data/a~01", want: ".data.a~1"},
+		{pointer: "/spec/containers/0/image", want: ".spec.containers.0.image"},
+		{pointer: "spec/replicas", wantErr: true},
+		{pointer: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			path, err := parseJSONPointer(tt.pointer)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJSONPointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := formatPath(path); got != tt.want {
+				t.Errorf("parseJSONPointer() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJQPath(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    bool
+	}{
+		{expression: ".spec.replicas", want: ".spec.replicas"},
+		{expression: `.metadata.annotations["example.com/name"]`, want: ".metadata.annotations.example.com/name"},
+		{expression: `.metadata.annotations["a\"]b"]`, want: `.metadata.annotations.a"]b`},
+		{expression: ".spec.containers[0].image", want: ".spec.containers[0].image"},
+		{expression: ".spec.containers[].image", want: ".spec.containers[].image"},
+		{expression: `.spec.containers[] | select(.name == "istio-proxy")`, want: ".spec.containers[name=istio-proxy]"},
+		{expression: `.spec.containers[] | select(.name == "a|b") | .image`, want: ".spec.containers[name=a|b].image"},
+		{expression: `.spec.ports[] | select(.name == "http" and .port == 80)`, want: ".spec.ports[name=http,port=80]"},
+		{expression: "", wantErr: true},
+		{expression: "spec.replicas", wantErr: true},
+		{expression: ".spec.containers[", wantErr: true},
+		{expression: ".spec.containers[-1]", wantErr: true},
+		{expression: ".spec.containers[first]", wantErr: true},
+		{expression: `.spec | select(.name == "a")`, wantErr: true},
+		{expression: `.spec.containers[] | select(.name != "a")`, wantErr: true},
+		{expression: `.spec.containers[] | select(.name == a)`, wantErr: true},
+		{expression: `.spec.containers[] | select(.a.b == 1)`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			path, err := parseJQPath(tt.expression)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJQPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := formatPath(path); got != tt.want {
+				t.Errorf("parseJQPath() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveFields(t *testing.T) {
+	const object = "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a}\n"
+
+	tests := []struct {
+		name    string
+		pointer string
+		jqPath  string
+		spec    string
+		want    string
+	}{
+		{
+			name:    "field",
+			pointer: "/spec/replicas",
+			spec:    "spec: {replicas: 2, paused: true}\n",
+			want:    "spec: {paused: true}\n",
+		},
+		{
+			name:    "missing path",
+			pointer: "/spec/template/spec",
+			spec:    "spec: {replicas: 2}\n",
+			want:    "spec: {replicas: 2}\n",
+		},
+		{
+			name:    "escaped key",
+			pointer: "/spec/selector/matchLabels/example.com~1name",
+			spec:    "spec: {selector: {matchLabels: {example.com/name: a, keep: b}}}\n",
+			want:    "spec: {selector: {matchLabels: {keep: b}}}\n",
+		},
+		{
+			name:    "list item by pointer",
+			pointer: "/spec/containers/1",
+			spec:    "spec: {containers: [{name: a}, {name: b}, {name: c}]}\n",
+			want:    "spec: {containers: [{name: a}, {name: c}]}\n",
+		},
+		{
+			name:   "fields of all items",
+			jqPath: ".spec.containers[].image",
+			spec:   "spec: {containers: [{name: a, image: a}, {name: b, image: b}]}\n",
+			want:   "spec: {containers: [{name: a}, {name: b}]}\n",
+		},
+		{
+			name:   "selected items",
+			jqPath: `.spec.containers[] | select(.name == "istio-proxy")`,
+			spec:   "spec: {containers: [{name: istio-proxy}, {name: a}, {name: istio-proxy}]}\n",
+			want:   "spec: {containers: [{name: a}]}\n",
+		},
+		{
+			name:   "index out of range",
+			jqPath: ".spec.containers[3]",
+			spec:   "spec: {containers: [{name: a}]}\n",
+			want:   "spec: {containers: [{name: a}]}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path []pathElement
+			var err error
+
+			if len(tt.pointer) > 0 {
+				path, err = parseJSONPointer(tt.pointer)
+			} else {
+				path, err = parseJQPath(tt.jqPath)
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			obj := newTestObject(t, object+tt.spec)
+			want := newTestObject(t, object+tt.want)
+
+			removeFields(obj.Object, path)
+
+			if !reflect.DeepEqual(obj.Object, want.Object) {
+				t.Errorf("removeFields() = %v, want %v", obj.Object, want.Object)
+			}
+		})
+	}
+}
+
+func TestRespectIgnoredFields(t *testing.T) {
+	const deployment = "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a}\n"
+
+	managedLive := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: a\n  managedFields:\n" +
+		"  - {manager: hpa, operation: Update, apiVersion: apps/v1, fieldsType: FieldsV1, fieldsV1: {'f:spec': {'f:replicas': {}}}}\n" +
+		"spec: {replicas: 5, paused: true}\n"
+
+	tests := []struct {
+		name       string
+		difference IgnoreDifference
+		desired    string
+		live       string
+		want       string
+	}{
+		{
+			name:       "live value is kept",
+			difference: IgnoreDifference{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+			desired:    deployment + "spec: {replicas: 3, paused: false}\n",
+			live:       deployment + "spec: {replicas: 5, paused: true}\n",
+			want:       deployment + "spec: {replicas: 5, paused: false}\n",
+		},
+		{
+			name:       "field absent in live object is not applied",
+			difference: IgnoreDifference{Group: "*", Kind: "*", JSONPointers: []string{"/spec/replicas"}},
+			desired:    deployment + "spec: {replicas: 3, paused: false}\n",
+			live:       deployment + "spec: {paused: true}\n",
+			want:       deployment + "spec: {paused: false}\n",
+		},
+		{
+			name:       "rule of other kind",
+			difference: IgnoreDifference{Group: "apps", Kind: "StatefulSet", JSONPointers: []string{"/spec/replicas"}},
+			desired:    deployment + "spec: {replicas: 3}\n",
+			live:       deployment + "spec: {replicas: 5}\n",
+			want:       deployment + "spec: {replicas: 3}\n",
+		},
+		{
+			name:       "rule of other name",
+			difference: IgnoreDifference{Group: "*", Kind: "*", Name: "b", JSONPointers: []string{"/spec/replicas"}},
+			desired:    deployment + "spec: {replicas: 3}\n",
+			live:       deployment + "spec: {replicas: 5}\n",
+			want:       deployment + "spec: {replicas: 3}\n",
+		},
+		{
+			name:       "selected list items",
+			difference: IgnoreDifference{Group: "*", Kind: "*", JQPathExpressions: []string{`.spec.containers[] | select(.name == "b") | .image`}},
+			desired:    deployment + "spec: {containers: [{name: a, image: a:2}, {name: b, image: b:2}]}\n",
+			live:       deployment + "spec: {containers: [{name: a, image: a:1}, {name: b, image: b:1}]}\n",
+			want:       deployment + "spec: {containers: [{name: a, image: a:2}, {name: b, image: b:1}]}\n",
+		},
+		{
+			name:       "reordered list items",
+			difference: IgnoreDifference{Group: "*", Kind: "*", JQPathExpressions: []string{`.spec.containers[] | select(.name == "b") | .image`}},
+			desired:    deployment + "spec: {containers: [{name: a, image: a:2}, {name: b, image: b:2}]}\n",
+			live:       deployment + "spec: {containers: [{name: b, image: b:1}, {name: a, image: a:1}]}\n",
+			want:       deployment + "spec: {containers: [{name: a, image: a:2}, {name: b, image: b:1}]}\n",
+		},
+		{
+			name:       "list item absent in live object",
+			difference: IgnoreDifference{Group: "*", Kind: "*", JSONPointers: []string{"/spec/containers/1/image"}},
+			desired:    deployment + "spec: {containers: [{name: a, image: a:2}, {name: b, image: b:2}]}\n",
+			live:       deployment + "spec: {containers: [{name: c, image: c:1}, {name: a, image: a:1}]}\n",
+			want:       deployment + "spec: {containers: [{name: a, image: a:2}, {name: b}]}\n",
+		},
+		{
+			name:       "fields of the manager",
+			difference: IgnoreDifference{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"hpa"}},
+			desired:    deployment + "spec: {replicas: 3, paused: false}\n",
+			live:       managedLive,
+			want:       deployment + "spec: {replicas: 5, paused: false}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := NewIgnoreRules([]IgnoreDifference{tt.difference})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			desired := newTestObject(t, tt.desired)
+
+			got := RespectIgnoredFields(desired, newTestObject(t, tt.live), rules)
+
+			if want := newTestObject(t, tt.want); !reflect.DeepEqual(got.Object, want.Object) {
+				t.Errorf("RespectIgnoredFields() = %v, want %v", got.Object, want.Object)
+			}
+
+			if !reflect.DeepEqual(desired.Object, newTestObject(t, tt.desired).Object) {
+				t.Errorf("RespectIgnoredFields() changed the desired object: %v", desired.Object)
+			}
+		})
+	}
+}
+
+func TestNewIgnoreRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		difference IgnoreDifference
+		wantErr    bool
+	}{
+		{"valid paths", IgnoreDifference{JSONPointers: []string{"/spec/replicas"}, JQPathExpressions: []string{".spec.replicas"}}, false},
+		{"bad json pointer", IgnoreDifference{JSONPointers: []string{"spec"}}, true},
+		{"bad jq path", IgnoreDifference{JQPathExpressions: []string{".spec["}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewIgnoreRules([]IgnoreDifference{tt.difference}); (err != nil) != tt.wantErr {
+				t.Errorf("NewIgnoreRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,163 @@
 package provider
 
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sort"
+	"time"
+)
+
+type SyncStatus string
+
+const (
+	SyncStatusSynced    SyncStatus = "Synced"
+	SyncStatusOutOfSync SyncStatus = "OutOfSync"
+	SyncStatusMissing   SyncStatus = "Missing"
+	SyncStatusUnknown   SyncStatus = "Unknown"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusHealthy     HealthStatus = "Healthy"
+	HealthStatusProgressing HealthStatus = "Progressing"
+	HealthStatusDegraded    HealthStatus = "Degraded"
+	HealthStatusMissing     HealthStatus = "Missing"
+)
+
+// healthSeverity orders health statuses from the best to the worst for aggregation
+var healthSeverity = map[HealthStatus]int{
+	HealthStatusHealthy:     0,
+	HealthStatusProgressing: 1,
+	HealthStatusMissing:     2,
+	HealthStatusDegraded:    3,
+}
+
+// ResourceDiff is the comparison result of desired and live object
+type ResourceDiff struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Status     SyncStatus
+	Message    string
+	// Fields are jq-style paths of the drifted fields
+	Fields []string
+}
+
+type ResourceOperation string
+
+const (
+	ResourceOperationCreated   ResourceOperation = "Created"
+	ResourceOperationUpdated   ResourceOperation = "Updated"
+	ResourceOperationUnchanged ResourceOperation = "Unchanged"
+	ResourceOperationReplaced  ResourceOperation = "Replaced"
+	ResourceOperationFailed    ResourceOperation = "Failed"
+)
+
+// ResourceResult is the outcome of delivering the object, Err is set for failed objects
+type ResourceResult struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Operation  ResourceOperation
+	Message    string
+	Err        error
+}
+
+type SyncRecordStatus string
+
+const (
+	SyncRecordSucceeded  SyncRecordStatus = "Succeeded"
+	SyncRecordFailed     SyncRecordStatus = "Failed"
+	SyncRecordRolledBack SyncRecordStatus = "RolledBack"
+)
+
+// SyncRecord is the history entry of the sync of the revision
+type SyncRecord struct {
+	Revision   string
+	Status     SyncRecordStatus
+	Message    string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Parameters overridden at runtime when the revision was synced
+	Parameters []HelmParameter
+}
+
+// ApplicationSync holds sync status of the last delivery, the resources out of sync and results of the delivered objects
+type ApplicationSync struct {
+	Status    SyncStatus
+	Resources []*ResourceDiff
+	Results   []*ResourceResult
+	// Prunes are objects removed from the sources and not deleted yet
+	Prunes []*ResourceDiff
+	// History of the syncs of the revisions, the latest is the last
+	History []*SyncRecord
+	// Parameters overridden at runtime
+	Parameters []HelmParameter
+	CheckedAt  time.Time
+}
+
+type ResourceHealth struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Status     HealthStatus
+	Message    string
+}
+
+// ApplicationHealth holds the worst health of the application resources
+type ApplicationHealth struct {
+	Status    HealthStatus
+	Resources []*ResourceHealth
+}
+
 type DeliveryProvider interface {
 	Delivery() error
 	Uninstall() error
+	// Render returns desired objects of the current revision without changing the cluster
+	Render() ([]*unstructured.Unstructured, error)
+	// Diff compares desired objects with live objects
+	Diff() ([]*ResourceDiff, error)
+	// Health returns health of live objects
+	Health() (*ApplicationHealth, error)
+	// Status returns sync status of the last delivery, nil before the first one
+	Status() *ApplicationSync
+}
+
+// NewApplicationHealth returns health aggregated from the resources, an application without resources is healthy
+func NewApplicationHealth(resources []*ResourceHealth) *ApplicationHealth {
+	health := &ApplicationHealth{Status: HealthStatusHealthy, Resources: resources}
+
+	for _, resource := range resources {
+		if healthSeverity[resource.Status] > healthSeverity[health.Status] {
+			health.Status = resource.Status
+		}
+	}
+
+	return health
+}
+
+// NewApplicationSync returns sync status of the resources out of sync sorted by kind, namespace and name
+func NewApplicationSync(resources []*ResourceDiff) *ApplicationSync {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+
+		if resources[i].Namespace != resources[j].Namespace {
+			return resources[i].Namespace < resources[j].Namespace
+		}
+
+		return resources[i].Name < resources[j].Name
+	})
+
+	sync := &ApplicationSync{Status: SyncStatusSynced, Resources: resources, CheckedAt: time.Now()}
+
+	if len(resources) > 0 {
+		sync.Status = SyncStatusOutOfSync
+	}
+
+	return sync
 }
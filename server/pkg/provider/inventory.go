@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	inventoryPrefix  = "dummycd-inventory-"
+	inventoryDataKey = "inventory"
+	// inventoryHookRevisionKey is the last revision delivered with its hooks, so hooks are not run again after restarts
+	inventoryHookRevisionKey = "dummy.cd/hook-revision"
+)
+
+// inventoryEntry identifies the object applied by the application
+type inventoryEntry struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// key identifies the object regardless of the API version it was applied with
+func (e *inventoryEntry) key() string {
+	return strings.Join([]string{e.Group, e.Kind, e.Namespace, e.Name}, "/")
+}
+
+func newInventoryEntry(resource *Resource) *inventoryEntry {
+	return &inventoryEntry{
+		Group:     resource.gvr.Group,
+		Version:   resource.gvr.Version,
+		Resource:  resource.gvr.Resource,
+		Kind:      resource.obj.GetKind(),
+		Namespace: resource.namespace,
+		Name:      resource.obj.GetName(),
+	}
+}
+
+// newResource returns resolved resource of the entry, the object holds only identity fields
+func (e *inventoryEntry) newResource() *Resource {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind})
+	obj.SetNamespace(e.Namespace)
+	obj.SetName(e.Name)
+
+	return &Resource{
+		gvr:        schema.GroupVersionResource{Group: e.Group, Version: e.Version, Resource: e.Resource},
+		namespaced: len(e.Namespace) > 0,
+		namespace:  e.Namespace,
+		filePath:   "inventory",
+		obj:        obj,
+	}
+}
+
+// inventoryName returns name of the ConfigMap holding objects applied by the application in its namespace
+func (r *RawProvider) inventoryName() string {
+	return inventoryPrefix + *r.appName
+}
+
+// getInventory returns objects applied by the application, objects labelled with the application
+// are discovered if the inventory does not exist, e.g. it was removed manually
+func (r *RawProvider) getInventory() (map[string]*inventoryEntry, error) {
+	configMap, err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Get(r.ctx, r.inventoryName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		r.logWithFields().Info("inventory not found, discovering labelled objects of the application")
+
+		return r.scanInventory()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*inventoryEntry
+
+	if err := json.Unmarshal([]byte(configMap.Data[inventoryDataKey]), &entries); err != nil {
+		return nil, fmt.Errorf("inventory %s/%s: %w", *r.namespace, r.inventoryName(), err)
+	}
+
+	inventory := make(map[string]*inventoryEntry)
+
+	for _, entry := range entries {
+		inventory[entry.key()] = entry
+	}
+
+	return inventory, nil
+}
+
+// initInventory creates the inventory before the first delivery of the application. Objects labelled by versions
+// without the inventory are discovered only if some of the resources are already delivered, the saved inventory
+// records that discovery is done, so it is never repeated
+func (r *RawProvider) initInventory(resources []*Resource) error {
+	r.inventoryMutex.Lock()
+	defer r.inventoryMutex.Unlock()
+
+	_, err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Get(r.ctx, r.inventoryName(), metav1.GetOptions{})
+
+	if !k8sErrors.IsNotFound(err) {
+		return err
+	}
+
+	delivered, err := r.isDelivered(resources)
+
+	if err != nil {
+		return err
+	}
+
+	inventory := make(map[string]*inventoryEntry)
+
+	if delivered {
+		r.logWithFields().Info("migrating to inventory, discovering labelled objects of the application")
+
+		inventory, err = r.scanInventory()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.saveInventory(inventory)
+}
+
+// isDelivered reports whether any of the resources is live and labelled with the application
+func (r *RawProvider) isDelivered(resources []*Resource) (bool, error) {
+	for _, resource := range resources {
+		// unresolved and not allowed resources are reported by their delivery
+		if err := resource.resolve(r.mapper, *r.namespace); err != nil {
+			continue
+		}
+
+		if err := r.checkAllowed(resource.obj.GroupVersionKind().GroupKind(), resource.namespaced, resource.namespace); err != nil {
+			continue
+		}
+
+		live, err := getLiveObject(r.ctx, r.dynamicClient, resource)
+
+		if err != nil {
+			return false, err
+		}
+
+		if live != nil && live.GetLabels()["dummy.cd/app"] == *r.appName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// scanInventory returns inventory of the live objects labelled with the application
+func (r *RawProvider) scanInventory() (map[string]*inventoryEntry, error) {
+	resources, err := r.scanResources()
+
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := make(map[string]*inventoryEntry)
+
+	for _, resource := range resources {
+		entry := newInventoryEntry(resource)
+		inventory[entry.key()] = entry
+	}
+
+	return inventory, nil
+}
+
+// saveInventory creates or updates the inventory ConfigMap, empty inventory is kept, so objects are not discovered again
+func (r *RawProvider) saveInventory(inventory map[string]*inventoryEntry) error {
+	configMaps := r.clientSet.CoreV1().ConfigMaps(*r.namespace)
+
+	entries := make([]*inventoryEntry, 0, len(inventory))
+
+	for _, entry := range inventory {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key() < entries[j].key()
+	})
+
+	data, err := json.Marshal(entries)
+
+	if err != nil {
+		return err
+	}
+
+	configMap, err := configMaps.Get(r.ctx, r.inventoryName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      r.inventoryName(),
+				Namespace: *r.namespace,
+				Labels:    map[string]string{"dummy.cd/inventory": *r.appName},
+			},
+			Data: map[string]string{inventoryDataKey: string(data)},
+		}
+
+		_, err = configMaps.Create(r.ctx, configMap, metav1.CreateOptions{})
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// annotations of the inventory are kept, they record state of the application
+	configMap.Data = map[string]string{inventoryDataKey: string(data)}
+
+	_, err = configMaps.Update(r.ctx, configMap, metav1.UpdateOptions{})
+
+	return err
+}
+
+// getHookRevision returns the last revision delivered with its hooks, empty revision if there is no inventory
+func (r *RawProvider) getHookRevision() (string, error) {
+	configMap, err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Get(r.ctx, r.inventoryName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return configMap.GetAnnotations()[inventoryHookRevisionKey], nil
+}
+
+// setHookRevision records the revision delivered with its hooks in the inventory
+func (r *RawProvider) setHookRevision(revision string) error {
+	r.inventoryMutex.Lock()
+	defer r.inventoryMutex.Unlock()
+
+	configMaps := r.clientSet.CoreV1().ConfigMaps(*r.namespace)
+
+	configMap, err := configMaps.Get(r.ctx, r.inventoryName(), metav1.GetOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	annotations := configMap.GetAnnotations()
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	annotations[inventoryHookRevisionKey] = revision
+	configMap.SetAnnotations(annotations)
+
+	_, err = configMaps.Update(r.ctx, configMap, metav1.UpdateOptions{})
+
+	return err
+}
+
+// updateInventory adds and removes objects of the inventory, the inventory is re-read under lock,
+// so concurrent delivery and cleanup do not lose each other changes
+func (r *RawProvider) updateInventory(add []*Resource, remove []*Resource) error {
+	r.inventoryMutex.Lock()
+	defer r.inventoryMutex.Unlock()
+
+	inventory, err := r.getInventory()
+
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range add {
+		entry := newInventoryEntry(resource)
+		inventory[entry.key()] = entry
+	}
+
+	for _, resource := range remove {
+		delete(inventory, newInventoryEntry(resource).key())
+	}
+
+	return r.saveInventory(inventory)
+}
+
+// scanResources returns live objects labelled with the application of cached preferred resources of every group,
+// it is used only to build inventory of applications delivered before inventory was introduced
+func (r *RawProvider) scanResources() ([]*Resource, error) {
+	apiResourceLists, err := r.mapper.ServerPreferredResources()
+
+	if err != nil && len(apiResourceLists) == 0 {
+		return nil, err
+	}
+
+	if err != nil {
+		r.logWithFields().Warn(err)
+	}
+
+	labelsSelector := metav1.FormatLabelSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"dummy.cd/app": *r.appName},
+	})
+
+	var liveResources []*Resource
+	var liveResourcesMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	blocker := make(chan struct{}, 10)
+
+	for _, apiResourceList := range apiResourceLists {
+		gv, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+
+		if err != nil {
+			continue
+		}
+
+		for _, apiResource := range apiResourceList.APIResources {
+			if !util.ContainsString(apiResource.Verbs, "list") || !util.ContainsString(apiResource.Verbs, "delete") {
+				continue
+			}
+
+			var namespaces []string
+
+			if apiResource.Namespaced {
+				namespaces = r.getManagedNamespaces()
+			} else if r.isClusterResourceAllowed(schema.GroupKind{Group: gv.Group, Kind: apiResource.Kind}) {
+				namespaces = []string{metav1.NamespaceAll}
+			}
+
+			for _, namespace := range namespaces {
+				blocker <- struct{}{}
+				wg.Add(1)
+				go func(gvr schema.GroupVersionResource, namespaced bool, namespace string) {
+					defer wg.Done()
+					defer func() { <-blocker }()
+
+					remoteResourceList, err := r.dynamicClient.Resource(gvr).Namespace(namespace).List(r.ctx, metav1.ListOptions{
+						LabelSelector: labelsSelector,
+					})
+
+					if err != nil {
+						r.logWithFields().Tracef("%s: %s", gvr, err)
+						return
+					}
+
+					liveResourcesMutex.Lock()
+					defer liveResourcesMutex.Unlock()
+
+					for i := range remoteResourceList.Items {
+						remoteResource := &remoteResourceList.Items[i]
+
+						liveResources = append(liveResources, &Resource{
+							gvr:        gvr,
+							namespaced: namespaced,
+							namespace:  remoteResource.GetNamespace(),
+							filePath:   "cluster",
+							obj:        remoteResource,
+						})
+					}
+				}(gv.WithResource(apiResource.Name), apiResource.Namespaced, namespace)
+			}
+		}
+	}
+
+	wg.Wait()
+
+	return liveResources, nil
+}
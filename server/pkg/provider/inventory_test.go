@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// newTestConfigMapClientSet returns client set of the test server keeping ConfigMaps of the default namespace by name
+func newTestConfigMapClientSet(t *testing.T, configMaps map[string]*corev1.ConfigMap) *kubernetes.Clientset {
+	t.Helper()
+
+	var mutex sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		name := path.Base(req.URL.Path)
+		configMap, exist := configMaps[name]
+
+		switch req.Method {
+		case http.MethodGet, http.MethodDelete:
+			if !exist {
+				writeTestStatus(w, http.StatusNotFound, "NotFound")
+				return
+			}
+
+			if req.Method == http.MethodDelete {
+				delete(configMaps, name)
+			}
+		case http.MethodPut, http.MethodPost:
+			if req.Method == http.MethodPut && !exist {
+				writeTestStatus(w, http.StatusNotFound, "NotFound")
+				return
+			}
+
+			if req.Method == http.MethodPost && exist {
+				writeTestStatus(w, http.StatusConflict, "AlreadyExists")
+				return
+			}
+
+			configMap = &corev1.ConfigMap{}
+
+			if err := json.NewDecoder(req.Body).Decode(configMap); err != nil {
+				writeTestStatus(w, http.StatusBadRequest, "BadRequest")
+				return
+			}
+
+			configMaps[configMap.Name] = configMap
+		}
+
+		_ = json.NewEncoder(w).Encode(configMap)
+	}))
+
+	t.Cleanup(server.Close)
+
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clientSet
+}
+
+func newTestInventoryResource(group string, resource string, kind string, namespace string, name string) *Resource {
+	entry := &inventoryEntry{Group: group, Version: "v1", Resource: resource, Kind: kind, Namespace: namespace, Name: name}
+
+	return entry.newResource()
+}
+
+func TestInventoryEntry(t *testing.T) {
+	resource := newTestInventoryResource("apps", "deployments", "Deployment", "default", "web")
+
+	if resource.gvr != (schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}) || !resource.namespaced {
+		t.Errorf("newResource() = %s namespaced %v", resource.gvr, resource.namespaced)
+	}
+
+	if got := newInventoryEntry(resource).key(); got != "apps/Deployment/default/web" {
+		t.Errorf("key() = %s, want apps/Deployment/default/web", got)
+	}
+
+	if clusterRole := newTestInventoryResource("rbac.authorization.k8s.io", "clusterroles", "ClusterRole", "", "a"); clusterRole.namespaced {
+		t.Error("newResource() of cluster-scoped entry is namespaced")
+	}
+}
+
+func TestUpdateInventory(t *testing.T) {
+	configMaps := map[string]*corev1.ConfigMap{
+		"dummycd-inventory-app": {
+			ObjectMeta: metav1.ObjectMeta{Name: "dummycd-inventory-app"},
+			Data:       map[string]string{inventoryDataKey: `[{"version": "v1", "resource": "configmaps", "kind": "ConfigMap", "namespace": "default", "name": "old"}]`},
+		},
+	}
+
+	r := newTestRawProvider(RawActionOptions{})
+	r.inventoryMutex = new(sync.Mutex)
+	r.clientSet = newTestConfigMapClientSet(t, configMaps)
+
+	old := newTestInventoryResource("", "configmaps", "ConfigMap", "default", "old")
+	web := newTestInventoryResource("apps", "deployments", "Deployment", "default", "web")
+	role := newTestInventoryResource("rbac.authorization.k8s.io", "clusterroles", "ClusterRole", "", "web")
+
+	if err := r.updateInventory([]*Resource{web, role}, []*Resource{old}); err != nil {
+		t.Fatal(err)
+	}
+
+	inventory, err := r.getInventory()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for key := range inventory {
+		got = append(got, key)
+	}
+
+	sort.Strings(got)
+
+	if want := []string{"apps/Deployment/default/web", "rbac.authorization.k8s.io/ClusterRole//web"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("inventory = %v, want %v", got, want)
+	}
+
+	if err := r.updateInventory(nil, []*Resource{web, role}); err != nil {
+		t.Fatal(err)
+	}
+
+	if configMap, exist := configMaps["dummycd-inventory-app"]; !exist || configMap.Data[inventoryDataKey] != "[]" {
+		t.Errorf("empty inventory is not kept: %v", configMap)
+	}
+}
+
+func TestInitInventory(t *testing.T) {
+	apiResources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list", "delete"}},
+			},
+		},
+	}
+
+	const legacy = `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "web", "namespace": "default", "labels": {"dummy.cd/app": "app"}}}`
+
+	tests := []struct {
+		name      string
+		inventory string
+		live      string
+		want      string
+		wantLists int
+	}{
+		{
+			name:      "existing inventory",
+			inventory: "[]",
+			live:      legacy,
+			want:      "[]",
+		},
+		{
+			name: "new application",
+			want: "[]",
+		},
+		{
+			name: "object of other application",
+			live: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "web", "namespace": "default", "labels": {"dummy.cd/app": "other"}}}`,
+			want: "[]",
+		},
+		{
+			name:      "legacy application",
+			live:      legacy,
+			want:      `[{"version":"v1","resource":"configmaps","kind":"ConfigMap","namespace":"default","name":"old"}]`,
+			wantLists: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMaps := make(map[string]*corev1.ConfigMap)
+
+			if len(tt.inventory) > 0 {
+				configMaps["dummycd-inventory-app"] = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "dummycd-inventory-app"},
+					Data:       map[string]string{inventoryDataKey: tt.inventory},
+				}
+			}
+
+			var lists int
+
+			mapper, fake := newTestResourceMapper(apiResources)
+			mapper.discovery = memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: fake})
+
+			r := newTestRawProvider(RawActionOptions{})
+			r.mapper = mapper
+			r.inventoryMutex = new(sync.Mutex)
+			r.clientSet = newTestConfigMapClientSet(t, configMaps)
+			r.dynamicClient = newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+				switch {
+				case req.URL.Path == "/api/v1/namespaces/default/configmaps":
+					lists++
+					_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMapList", "items": [{"apiVersion": "v1", "kind": "ConfigMap", ` +
+						`"metadata": {"name": "old", "namespace": "default", "labels": {"dummy.cd/app": "app"}}}]}`))
+				case len(tt.live) > 0:
+					_, _ = w.Write([]byte(tt.live))
+				default:
+					writeTestStatus(w, http.StatusNotFound, "NotFound")
+				}
+			})
+
+			resources := []*Resource{{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: web}\n")}}
+
+			if err := r.initInventory(resources); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := configMaps["dummycd-inventory-app"].Data[inventoryDataKey]; got != tt.want {
+				t.Errorf("initInventory() inventory = %s, want %s", got, tt.want)
+			}
+
+			if lists != tt.wantLists {
+				t.Errorf("initInventory() listed objects %d times, want %d", lists, tt.wantLists)
+			}
+		})
+	}
+}
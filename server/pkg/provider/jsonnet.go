@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-jsonnet"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// JsonnetDefaultEntrypoint is evaluated when JsonnetProvider.Entrypoint is not set
+const JsonnetDefaultEntrypoint = "main.jsonnet"
+
+type JsonnetVariable struct {
+	Name  string
+	Value string
+	// Code evaluates Value as jsonnet code instead of string
+	Code bool
+}
+
+// JsonnetProvider delivers objects evaluated from jsonnet entrypoint with RawProvider apply and prune semantics
+type JsonnetProvider struct {
+	// Entrypoint relative to the application sparse path
+	Entrypoint string
+	// JPaths are library directories relative to the repository root
+	JPaths  []string
+	ExtVars []JsonnetVariable
+	TLAs    []JsonnetVariable
+	raw     *RawProvider
+	sources *string
+	root    *string
+}
+
+// IsJsonnetDir reports whether the directory contains the entrypoint
+func IsJsonnetDir(path string, entrypoint string) bool {
+	if len(entrypoint) == 0 {
+		entrypoint = JsonnetDefaultEntrypoint
+	}
+
+	info, err := os.Stat(filepath.Join(path, entrypoint))
+
+	return err == nil && !info.IsDir()
+}
+
+// NewJsonnetResources returns resources of the objects evaluated from the entrypoint,
+// the output can be an object, an array or nested objects of kubernetes objects
+func NewJsonnetResources(entrypoint string, jpaths []string, extVars []JsonnetVariable, tlas []JsonnetVariable) ([]*Resource, error) {
+	vm := jsonnet.MakeVM()
+
+	vm.Importer(&jsonnet.FileImporter{JPaths: jpaths})
+
+	for _, v := range extVars {
+		if v.Code {
+			vm.ExtCode(v.Name, v.Value)
+		} else {
+			vm.ExtVar(v.Name, v.Value)
+		}
+	}
+
+	for _, v := range tlas {
+		if v.Code {
+			vm.TLACode(v.Name, v.Value)
+		} else {
+			vm.TLAVar(v.Name, v.Value)
+		}
+	}
+
+	output, err := vm.EvaluateFile(entrypoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(output)))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var resources []*Resource
+
+	for document, obj := range collectJsonnetObjects(value) {
+		data, err := json.Marshal(obj)
+
+		if err != nil {
+			return nil, err
+		}
+
+		documentResources, err := NewDocumentResources(data, entrypoint, document)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, documentResources...)
+	}
+
+	return resources, nil
+}
+
+// collectJsonnetObjects returns kubernetes objects of the value, nested objects are walked in key order
+func collectJsonnetObjects(value interface{}) []map[string]interface{} {
+	var objs []map[string]interface{}
+
+	switch t := value.(type) {
+	case []interface{}:
+		for _, item := range t {
+			objs = append(objs, collectJsonnetObjects(item)...)
+		}
+	case map[string]interface{}:
+		_, hasKind := t["kind"]
+		_, hasAPIVersion := t["apiVersion"]
+
+		if hasKind && hasAPIVersion {
+			return append(objs, t)
+		}
+
+		var keys []string
+
+		for key := range t {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			objs = append(objs, collectJsonnetObjects(t[key])...)
+		}
+	default:
+		log.Debugf("skip jsonnet output value %v", t)
+	}
+
+	return objs
+}
+
+func NewJsonnetProvider(ctx context.Context, appName *string, sourcePath *string, repositoryPath *string,
+	namespace *string, jsonnetProvider *JsonnetProvider, raw *RawProvider, restKubeConfig *rest.Config, appRevision *plumbing.Hash) (*JsonnetProvider, error) {
+
+	var err error
+
+	jsonnetProvider.sources = sourcePath
+	jsonnetProvider.root = repositoryPath
+
+	raw.render = jsonnetProvider.render
+
+	jsonnetProvider.raw, err = NewRawProvider(ctx, appName, &[]string{*sourcePath}, nil, namespace, raw, restKubeConfig, appRevision)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonnetProvider, nil
+}
+
+func (j *JsonnetProvider) render() ([]*Resource, error) {
+	var jpaths []string
+
+	for _, jpath := range j.JPaths {
+		jpaths = append(jpaths, filepath.Join(*j.root, jpath))
+	}
+
+	entrypoint := j.Entrypoint
+
+	if len(entrypoint) == 0 {
+		entrypoint = JsonnetDefaultEntrypoint
+	}
+
+	return NewJsonnetResources(filepath.Join(*j.sources, entrypoint), jpaths, j.ExtVars, j.TLAs)
+}
+
+func (j *JsonnetProvider) Delivery() error {
+	return j.raw.Delivery()
+}
+
+func (j *JsonnetProvider) Uninstall() error {
+	return j.raw.Uninstall()
+}
+
+func (j *JsonnetProvider) Render() ([]*unstructured.Unstructured, error) {
+	return j.raw.Render()
+}
+
+func (j *JsonnetProvider) Diff() ([]*ResourceDiff, error) {
+	return j.raw.Diff()
+}
+
+func (j *JsonnetProvider) Health() (*ApplicationHealth, error) {
+	return j.raw.Health()
+}
+
+func (j *JsonnetProvider) Status() *ApplicationSync {
+	return j.raw.Status()
+}
+
+func (j *JsonnetProvider) ConfirmPrune() error {
+	return j.raw.ConfirmPrune()
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewJsonnetResources(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{
+		"lib/config.libsonnet": "{ configMap(name):: { apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: name } } }\n",
+		"app/main.jsonnet": "local config = import 'config.libsonnet';\n" +
+			"function(replicas=1) {\n" +
+			"  b: { apiVersion: 'apps/v1', kind: 'Deployment', metadata: { name: std.extVar('name') }, spec: { replicas: replicas } },\n" +
+			"  a: [config.configMap('a'), config.configMap('b')],\n" +
+			"  ignored: 'value',\n" +
+			"}\n",
+	})
+
+	resources, err := NewJsonnetResources(filepath.Join(root, "app", JsonnetDefaultEntrypoint), []string{filepath.Join(root, "lib")},
+		[]JsonnetVariable{{Name: "name", Value: "web"}}, []JsonnetVariable{{Name: "replicas", Value: "3", Code: true}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for _, resource := range resources {
+		replicas, _, _ := unstructured.NestedInt64(resource.obj.Object, "spec", "replicas")
+		got = append(got, fmt.Sprintf("%d %s %s %d", resource.document, resource.obj.GetKind(), resource.obj.GetName(), replicas))
+	}
+
+	want := []string{"0 ConfigMap a 0", "1 ConfigMap b 0", "2 Deployment web 3"}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("NewJsonnetResources() = %v, want %v", got, want)
+	}
+}
+
+func TestNewJsonnetResourcesInvalid(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{
+		"syntax.jsonnet":   "{ a: }\n",
+		"nameless.jsonnet": "{ apiVersion: 'v1', kind: 'ConfigMap', metadata: {} }\n",
+	})
+
+	for _, entrypoint := range []string{"syntax.jsonnet", "nameless.jsonnet", "missing.jsonnet"} {
+		if _, err := NewJsonnetResources(filepath.Join(root, entrypoint), nil, nil, nil); err == nil {
+			t.Errorf("NewJsonnetResources(%s) returned no error", entrypoint)
+		}
+	}
+}
+
+func TestIsJsonnetDir(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{"main.jsonnet": "{}\n", "app.jsonnet": "{}\n"})
+
+	tests := []struct {
+		entrypoint string
+		want       bool
+	}{
+		{"", true},
+		{"app.jsonnet", true},
+		{"other.jsonnet", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsJsonnetDir(root, tt.entrypoint); got != tt.want {
+			t.Errorf("IsJsonnetDir(%q) = %v, want %v", tt.entrypoint, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"github.com/go-git/go-git/v5/plumbing"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// KustomizeProvider delivers resources built from kustomization with RawProvider apply and prune semantics
+type KustomizeProvider struct {
+	raw               *RawProvider
+	kustomizationPath *string
+}
+
+// IsKustomizationDir reports whether the directory contains kustomization file
+func IsKustomizationDir(path string) bool {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		if info, err := os.Stat(filepath.Join(path, fileName)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewKustomizeResources returns resources built in-process from the kustomization directory,
+// bases outside the directory have to be checked out with application sparse paths
+func NewKustomizeResources(kustomizationPath string) ([]*Resource, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), kustomizationPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*Resource
+
+	for document, res := range resMap.Resources() {
+		data, err := res.AsYAML()
+
+		if err != nil {
+			return nil, err
+		}
+
+		documentResources, err := NewDocumentResources(data, kustomizationPath, document)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, documentResources...)
+	}
+
+	return resources, nil
+}
+
+func NewKustomizeProvider(ctx context.Context, appName *string, kustomizationPath *string,
+	namespace *string, raw *RawProvider, restKubeConfig *rest.Config, appRevision *plumbing.Hash) (*KustomizeProvider, error) {
+
+	kustomize := &KustomizeProvider{
+		kustomizationPath: kustomizationPath,
+	}
+
+	raw.render = kustomize.render
+
+	var err error
+
+	kustomize.raw, err = NewRawProvider(ctx, appName, &[]string{*kustomizationPath}, nil, namespace, raw, restKubeConfig, appRevision)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return kustomize, nil
+}
+
+func (k *KustomizeProvider) render() ([]*Resource, error) {
+	return NewKustomizeResources(*k.kustomizationPath)
+}
+
+func (k *KustomizeProvider) Delivery() error {
+	return k.raw.Delivery()
+}
+
+func (k *KustomizeProvider) Uninstall() error {
+	return k.raw.Uninstall()
+}
+
+func (k *KustomizeProvider) Render() ([]*unstructured.Unstructured, error) {
+	return k.raw.Render()
+}
+
+func (k *KustomizeProvider) Diff() ([]*ResourceDiff, error) {
+	return k.raw.Diff()
+}
+
+func (k *KustomizeProvider) Health() (*ApplicationHealth, error) {
+	return k.raw.Health()
+}
+
+func (k *KustomizeProvider) Status() *ApplicationSync {
+	return k.raw.Status()
+}
+
+func (k *KustomizeProvider) ConfirmPrune() error {
+	return k.raw.ConfirmPrune()
+}
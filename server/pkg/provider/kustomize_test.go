@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFiles writes the files with paths relative to the root
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNewKustomizeResources(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{
+		"base/kustomization.yaml": "resources: [resources.yaml]\n",
+		"base/resources.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: config}\n---\n" +
+			"apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: app}\n",
+		"overlay/kustomization.yaml": "resources: [../base]\nnamePrefix: prod-\ncommonLabels: {env: prod}\n",
+	})
+
+	resources, err := NewKustomizeResources(filepath.Join(root, "overlay"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+
+	for _, resource := range resources {
+		got = append(got, fmt.Sprintf("%d %s %s %s", resource.document, resource.obj.GetKind(), resource.obj.GetName(), resource.obj.GetLabels()["env"]))
+	}
+
+	want := []string{"0 ConfigMap prod-config prod", "1 Deployment prod-app prod"}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("NewKustomizeResources() = %v, want %v", got, want)
+	}
+}
+
+func TestNewKustomizeResourcesInvalid(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{"kustomization.yaml": "resources: [missing.yaml]\n"})
+
+	if _, err := NewKustomizeResources(root); err == nil {
+		t.Error("NewKustomizeResources() with missing resource returned no error")
+	}
+}
+
+func TestIsKustomizationDir(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{
+		"a/kustomization.yml": "resources: []\n",
+		"b/Kustomization":     "resources: []\n",
+		"c/deployment.yaml":   "",
+	})
+
+	for dir, want := range map[string]bool{"a": true, "b": true, "c": false, "missing": false} {
+		if got := IsKustomizationDir(filepath.Join(root, dir)); got != want {
+			t.Errorf("IsKustomizationDir(%s) = %v, want %v", dir, got, want)
+		}
+	}
+}
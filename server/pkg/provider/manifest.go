@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"io"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// SplitYAMLDocuments returns documents of multi-document yaml, json is returned as single document
+func SplitYAMLDocuments(data []byte) ([][]byte, error) {
+	var documents [][]byte
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	for {
+		document, err := reader.Read()
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// NewUnstructuredObjects decodes yaml or json document, json arrays and List kinds are expanded to its items
+func NewUnstructuredObjects(document []byte) ([]*unstructured.Unstructured, error) {
+	data, err := yaml.YAMLToJSON(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	if data[0] == '[' {
+		var items []json.RawMessage
+
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+
+		var objs []*unstructured.Unstructured
+
+		for i, item := range items {
+			itemObjs, err := NewUnstructuredObjects(item)
+
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+
+			objs = append(objs, itemObjs...)
+		}
+
+		return objs, nil
+	}
+
+	obj, _, err := unstructured.UnstructuredJSONScheme.Decode(data, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := obj.(type) {
+	case *unstructured.UnstructuredList:
+		var objs []*unstructured.Unstructured
+
+		for i := range t.Items {
+			objs = append(objs, &t.Items[i])
+		}
+
+		return objs, nil
+	case *unstructured.Unstructured:
+		return []*unstructured.Unstructured{t}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected object type %T", obj)
+}
+
+// NewManifestResources returns resources of multi-document yaml manifest, e.g. rendered by helm or a plugin
+func NewManifestResources(manifest []byte, source string) ([]*Resource, error) {
+	var resources []*Resource
+
+	documents, err := SplitYAMLDocuments(manifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	for document, data := range documents {
+		documentResources, err := NewDocumentResources(data, source, document)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, documentResources...)
+	}
+
+	return resources, nil
+}
+
+// NewDocumentResources returns resources of the objects of yaml or json document of the source, lists are expanded
+func NewDocumentResources(data []byte, source string, document int) ([]*Resource, error) {
+	objs, err := NewUnstructuredObjects(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: document %d: %w: %s", source, document, util.ErrInvalidResource, err)
+	}
+
+	var resources []*Resource
+
+	for _, obj := range objs {
+		resource, err := NewUnstructuredResource(obj, source, document)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
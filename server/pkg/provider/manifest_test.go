@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewUnstructuredResourcesFromFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:     "multiple documents",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n---\napiVersion: v1\nkind: Secret\nmetadata: {name: b}\n",
+			want:     []string{"0 ConfigMap a", "1 Secret b"},
+		},
+		{
+			name:     "empty documents and comments",
+			manifest: "---\n# comment\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n---\n",
+			want:     []string{"1 ConfigMap a"},
+		},
+		{
+			name: "list kind",
+			manifest: "apiVersion: v1\nkind: List\nitems:\n" +
+				"- {apiVersion: v1, kind: ConfigMap, metadata: {name: a}}\n- {apiVersion: v1, kind: Secret, metadata: {name: b}}\n",
+			want: []string{"0 ConfigMap a", "0 Secret b"},
+		},
+		{
+			name:     "typed list kind",
+			manifest: "apiVersion: v1\nkind: ConfigMapList\nitems: [{apiVersion: v1, kind: ConfigMap, metadata: {name: a}}]\n",
+			want:     []string{"0 ConfigMap a"},
+		},
+		{
+			name:     "json array",
+			manifest: `[{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}, [{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "b"}}]]`,
+			want:     []string{"0 ConfigMap a", "0 Secret b"},
+		},
+		{
+			name:     "missing name",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {}\n",
+			wantErr:  util.ErrInvalidResource,
+		},
+		{
+			name:     "missing kind",
+			manifest: "apiVersion: v1\nmetadata: {name: a}\n",
+			wantErr:  util.ErrInvalidResource,
+		},
+		{
+			name:     "invalid yaml",
+			manifest: "apiVersion: v1\nkind: [ConfigMap\n",
+			wantErr:  util.ErrInvalidResource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.yaml")
+
+			if err := os.WriteFile(path, []byte(tt.manifest), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			resources, err := NewUnstructuredResourcesFromFile(path)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewUnstructuredResourcesFromFile() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var got []string
+
+			for _, resource := range resources {
+				got = append(got, fmt.Sprintf("%d %s %s", resource.document, resource.obj.GetKind(), resource.obj.GetName()))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("NewUnstructuredResourcesFromFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewManifestResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name: "documents and list",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n---\n" +
+				"apiVersion: v1\nkind: List\nitems: [{apiVersion: v1, kind: Secret, metadata: {name: b}}]\n",
+			want: []string{"0 ConfigMap a", "1 Secret b"},
+		},
+		{
+			name:     "invalid document",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n---\napiVersion: v1\nkind: [Secret\n",
+			wantErr:  "rendered: document 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := NewManifestResources([]byte(tt.manifest), "rendered")
+
+			if len(tt.wantErr) > 0 {
+				if !errors.Is(err, util.ErrInvalidResource) || !strings.Contains(err.Error(), tt.wantErr) || resources != nil {
+					t.Fatalf("NewManifestResources() = %d resources, error %v, want error %s", len(resources), err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+
+			for _, resource := range resources {
+				got = append(got, fmt.Sprintf("%d %s %s", resource.document, resource.obj.GetKind(), resource.obj.GetName()))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("NewManifestResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	documents, err := SplitYAMLDocuments([]byte("a: 1\n---\nb: 2\n---\nc: 3\n"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(documents) != 3 {
+		t.Errorf("SplitYAMLDocuments() returned %d documents, want 3: %q", len(documents), documents)
+	}
+}
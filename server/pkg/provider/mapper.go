@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"flag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sync"
+	"time"
+)
+
+var (
+	restMapperResetInterval = flag.Duration("rest-mapper-reset-interval", 10*time.Second, "min interval to refresh cached discovery when kind is not found")
+
+	resourceMappers      = make(map[string]*ResourceMapper)
+	resourceMappersMutex = new(sync.Mutex)
+)
+
+// ResourceMapper resolves GroupVersionKind to GroupVersionResource and scope using cached discovery
+type ResourceMapper struct {
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+	discovery discovery.CachedDiscoveryInterface
+	mutex     *sync.Mutex
+	lastReset time.Time
+}
+
+// NewResourceMapper returns ResourceMapper shared between providers of the same cluster
+func NewResourceMapper(restKubeConfig *rest.Config) (*ResourceMapper, error) {
+	resourceMappersMutex.Lock()
+	defer resourceMappersMutex.Unlock()
+
+	if resourceMapper, exist := resourceMappers[restKubeConfig.Host]; exist {
+		return resourceMapper, nil
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restKubeConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cachedDiscoveryClient := memory.NewMemCacheClient(discoveryClient)
+
+	resourceMapper := &ResourceMapper{
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscoveryClient),
+		discovery: cachedDiscoveryClient,
+		mutex:     new(sync.Mutex),
+		lastReset: time.Now(),
+	}
+
+	resourceMappers[restKubeConfig.Host] = resourceMapper
+
+	return resourceMapper, nil
+}
+
+// RESTMapping returns mapping for the kind, cached discovery is refreshed once when the kind is not found, e.g. for new CRDs
+func (m *ResourceMapper) RESTMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := m.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+
+	if err != nil && meta.IsNoMatchError(err) && m.tryReset() {
+		mapping, err = m.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+
+	return mapping, err
+}
+
+// ServerPreferredResources returns cached resources of the preferred version of every group,
+// resources of groups failed to discover are returned with the error
+func (m *ResourceMapper) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return m.discovery.ServerPreferredResources()
+}
+
+// Reset invalidates cached discovery
+func (m *ResourceMapper) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.mapper.Reset()
+	m.lastReset = time.Now()
+}
+
+func (m *ResourceMapper) tryReset() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if time.Since(m.lastReset) < *restMapperResetInterval {
+		return false
+	}
+
+	m.mapper.Reset()
+	m.lastReset = time.Now()
+
+	return true
+}
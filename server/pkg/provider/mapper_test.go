@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/restmapper"
+	clienttesting "k8s.io/client-go/testing"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testAPIResources are served by discovery of the test resource mapper
+var testAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			{Name: "namespaces", Kind: "Namespace"},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true},
+		},
+	},
+	{
+		GroupVersion: "rbac.authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "clusterroles", Kind: "ClusterRole"},
+		},
+	},
+	{
+		GroupVersion: "apiextensions.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition"},
+		},
+	},
+	{
+		GroupVersion: "networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "ingresses", Kind: "Ingress", Namespaced: true},
+		},
+	},
+}
+
+// newTestResourceMapper returns ResourceMapper of fake discovery serving the resources
+func newTestResourceMapper(resources []*metav1.APIResourceList) (*ResourceMapper, *clienttesting.Fake) {
+	fake := &clienttesting.Fake{Resources: resources}
+
+	return &ResourceMapper{
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: fake})),
+		mutex:     new(sync.Mutex),
+		lastReset: time.Now(),
+	}, fake
+}
+
+func TestResourceMapperRESTMapping(t *testing.T) {
+	mapper, _ := newTestResourceMapper(testAPIResources)
+
+	tests := []struct {
+		gvk        schema.GroupVersionKind
+		want       schema.GroupVersionResource
+		namespaced bool
+	}{
+		{
+			gvk:        schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"},
+			want:       schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+			namespaced: true,
+		},
+		{
+			gvk:  schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+			want: schema.GroupVersionResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gvk.Kind, func(t *testing.T) {
+			mapping, err := mapper.RESTMapping(tt.gvk)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if mapping.Resource != tt.want {
+				t.Errorf("RESTMapping() resource = %s, want %s", mapping.Resource, tt.want)
+			}
+
+			if namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace; namespaced != tt.namespaced {
+				t.Errorf("RESTMapping() namespaced = %v, want %v", namespaced, tt.namespaced)
+			}
+		})
+	}
+}
+
+func TestResourceMapperNewKind(t *testing.T) {
+	interval := *restMapperResetInterval
+	defer func() { *restMapperResetInterval = interval }()
+
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	mapper, fake := newTestResourceMapper(append([]*metav1.APIResourceList{}, testAPIResources...))
+
+	if _, err := mapper.RESTMapping(gvk); !meta.IsNoMatchError(err) {
+		t.Fatalf("RESTMapping() of unknown kind error = %v, want no match", err)
+	}
+
+	// the CRD is created after discovery is cached
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget", Namespaced: true}},
+	})
+
+	*restMapperResetInterval = time.Hour
+
+	if _, err := mapper.RESTMapping(gvk); !meta.IsNoMatchError(err) {
+		t.Fatalf("RESTMapping() refreshed discovery within the reset interval: %v", err)
+	}
+
+	*restMapperResetInterval = 0
+
+	mapping, err := mapper.RESTMapping(gvk)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mapping.Resource.Resource != "widgets" {
+		t.Errorf("RESTMapping() resource = %s, want widgets", mapping.Resource)
+	}
+}
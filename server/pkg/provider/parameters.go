@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ParameterTypeAuto is --set, numbers, booleans and null are typed
+	ParameterTypeAuto   = ""
+	ParameterTypeString = "string"
+	ParameterTypeJSON   = "json"
+	// ParameterTypeFile is --set-file, the value is path of the file relative to the chart path
+	ParameterTypeFile = "file"
+
+	parametersPrefix  = "dummycd-parameters-"
+	parametersDataKey = "parameters"
+)
+
+// HelmParameter overrides the value at the path of the name like --set flags
+type HelmParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// ParameterOverrider is implemented by providers which can override parameters at runtime without a new revision
+type ParameterOverrider interface {
+	// OverrideParameters replaces the runtime overrides, they are applied by the next delivery, empty parameters remove them
+	OverrideParameters(parameters []HelmParameter) error
+}
+
+// mergeParameters returns the parameters with the overrides replacing the parameters of the same name
+func mergeParameters(parameters []HelmParameter, overrides []HelmParameter) []HelmParameter {
+	var merged []HelmParameter
+
+	index := make(map[string]int)
+
+	for _, parameter := range append(append([]HelmParameter{}, parameters...), overrides...) {
+		if i, ok := index[parameter.Name]; ok {
+			merged[i] = parameter
+			continue
+		}
+
+		index[parameter.Name] = len(merged)
+		merged = append(merged, parameter)
+	}
+
+	return merged
+}
+
+// escapeParameterValue escapes separators of --set values, so the value is never split into several parameters
+func escapeParameterValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`).Replace(value)
+}
+
+// newParametersOptions returns values options of the parameters, files of the parameters have to be in the chart path
+func newParametersOptions(parameters []HelmParameter, chartPath string) (*values.Options, error) {
+	options := &values.Options{}
+
+	for _, parameter := range parameters {
+		if len(parameter.Name) == 0 {
+			return nil, fmt.Errorf("%w: empty name", util.ErrInvalidParameter)
+		}
+
+		switch parameter.Type {
+		case ParameterTypeAuto:
+			options.Values = append(options.Values, parameter.Name+"="+escapeParameterValue(parameter.Value))
+		case ParameterTypeString:
+			options.StringValues = append(options.StringValues, parameter.Name+"="+escapeParameterValue(parameter.Value))
+		case ParameterTypeJSON:
+			options.JSONValues = append(options.JSONValues, parameter.Name+"="+parameter.Value)
+		case ParameterTypeFile:
+			filePath := filepath.Join(chartPath, parameter.Value)
+
+			if !strings.HasPrefix(filePath, filepath.Clean(chartPath)+string(filepath.Separator)) {
+				return nil, fmt.Errorf("%w: %s file %s is out of the chart path", util.ErrInvalidParameter, parameter.Name, parameter.Value)
+			}
+
+			options.FileValues = append(options.FileValues, parameter.Name+"="+escapeParameterValue(filePath))
+		default:
+			return nil, fmt.Errorf("%w: %s unknown type %s", util.ErrInvalidParameter, parameter.Name, parameter.Type)
+		}
+	}
+
+	return options, nil
+}
+
+// parametersName returns name of the ConfigMap holding runtime overrides in the application namespace
+func (h *HelmProvider) parametersName() string {
+	return parametersPrefix + *h.releaseName
+}
+
+// getOverrides returns parameters overridden at runtime
+func (h *HelmProvider) getOverrides() ([]HelmParameter, error) {
+	configMap, err := h.clientSet.CoreV1().ConfigMaps(*h.namespace).Get(context.Background(), h.parametersName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []HelmParameter
+
+	if err := json.Unmarshal([]byte(configMap.Data[parametersDataKey]), &overrides); err != nil {
+		return nil, fmt.Errorf("parameters %s/%s: %w", *h.namespace, h.parametersName(), err)
+	}
+
+	return overrides, nil
+}
+
+// loadOverrides reads the runtime overrides once per delivery, they are reported by the status and the history
+func (h *HelmProvider) loadOverrides() error {
+	overrides, err := h.getOverrides()
+
+	if err != nil {
+		return err
+	}
+
+	h.statusMutex.Lock()
+	h.overrides = overrides
+	h.statusMutex.Unlock()
+
+	return nil
+}
+
+// OverrideParameters checks the parameters and stores them in the application namespace, so they survive restarts
+func (h *HelmProvider) OverrideParameters(parameters []HelmParameter) error {
+	options, err := newParametersOptions(parameters, *h.chartPath)
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
+	if _, err := options.MergeValues(getter.All(h.settings)); err != nil {
+		h.logWithFields().Error(err)
+		return fmt.Errorf("%w: %w", util.ErrInvalidParameter, err)
+	}
+
+	configMaps := h.clientSet.CoreV1().ConfigMaps(*h.namespace)
+
+	if len(parameters) == 0 {
+		err := configMaps.Delete(context.Background(), h.parametersName(), metav1.DeleteOptions{})
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			h.logWithFields().Error(err)
+			return err
+		}
+
+		h.logWithFields().Info("parameter overrides removed")
+
+		return nil
+	}
+
+	data, err := json.Marshal(parameters)
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      h.parametersName(),
+			Namespace: *h.namespace,
+			Labels:    map[string]string{"dummy.cd/parameters": *h.releaseName},
+		},
+		Data: map[string]string{parametersDataKey: string(data)},
+	}
+
+	_, err = configMaps.Update(context.Background(), configMap, metav1.UpdateOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		_, err = configMaps.Create(context.Background(), configMap, metav1.CreateOptions{})
+	}
+
+	if err != nil {
+		h.logWithFields().Error(err)
+		return err
+	}
+
+	h.logWithFields().Infof("%d parameters overridden", len(parameters))
+
+	return nil
+}
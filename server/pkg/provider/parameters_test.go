@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
+	corev1 "k8s.io/api/core/v1"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeParameters(t *testing.T) {
+	parameters := []HelmParameter{{Name: "replicas", Value: "1"}, {Name: "image.tag", Value: "1.0"}}
+	overrides := []HelmParameter{{Name: "image.tag", Value: "2.0", Type: ParameterTypeString}, {Name: "debug", Value: "true"}}
+
+	got := mergeParameters(parameters, overrides)
+
+	want := []HelmParameter{
+		{Name: "replicas", Value: "1"},
+		{Name: "image.tag", Value: "2.0", Type: ParameterTypeString},
+		{Name: "debug", Value: "true"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeParameters() = %v, want %v", got, want)
+	}
+
+	if parameters[1].Value != "1.0" {
+		t.Errorf("mergeParameters() changed the parameters: %v", parameters)
+	}
+}
+
+func TestNewParametersOptions(t *testing.T) {
+	chartPath := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(chartPath, "config.txt"), []byte("a=b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		parameters []HelmParameter
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "typed value",
+			parameters: []HelmParameter{{Name: "replicas", Value: "2"}, {Name: "enabled", Value: "true"}},
+			want:       "map[enabled:true replicas:2]",
+		},
+		{
+			name:       "separators are escaped",
+			parameters: []HelmParameter{{Name: "hosts", Value: `a,b\c`}},
+			want:       `map[hosts:a,b\c]`,
+		},
+		{
+			name:       "string value",
+			parameters: []HelmParameter{{Name: "image.tag", Value: "1.10", Type: ParameterTypeString}},
+			want:       "map[image:map[tag:1.10]]",
+		},
+		{
+			name:       "json value",
+			parameters: []HelmParameter{{Name: "resources", Value: `{"limits": {"cpu": "1"}}`, Type: ParameterTypeJSON}},
+			want:       "map[resources:map[limits:map[cpu:1]]]",
+		},
+		{
+			name:       "file in the chart path",
+			parameters: []HelmParameter{{Name: "config", Value: "config.txt", Type: ParameterTypeFile}},
+			want:       "map[config:a=b]",
+		},
+		{
+			name:       "file out of the chart path",
+			parameters: []HelmParameter{{Name: "config", Value: "../config.txt", Type: ParameterTypeFile}},
+			wantErr:    util.ErrInvalidParameter,
+		},
+		{
+			name:       "empty name",
+			parameters: []HelmParameter{{Value: "1"}},
+			wantErr:    util.ErrInvalidParameter,
+		},
+		{
+			name:       "unknown type",
+			parameters: []HelmParameter{{Name: "a", Value: "1", Type: "yaml"}},
+			wantErr:    util.ErrInvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := newParametersOptions(tt.parameters, chartPath)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newParametersOptions() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			values, err := options.MergeValues(getter.Providers{})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := fmt.Sprint(values); got != tt.want {
+				t.Errorf("values of the parameters = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	releaseName, namespace, chartPath := "app", "default", t.TempDir()
+
+	h := &HelmProvider{
+		Parameters:  []HelmParameter{{Name: "replicas", Value: "1"}, {Name: "image.tag", Value: "1.0"}},
+		releaseName: &releaseName,
+		namespace:   &namespace,
+		chartPath:   &chartPath,
+		settings:    cli.New(),
+		clientSet: newTestConfigMapClientSet(t, map[string]*corev1.ConfigMap{
+			"dummycd-parameters-app": {Data: map[string]string{parametersDataKey: `[{"name": "image.tag", "value": "2.0", "type": "string"}]`}},
+		}),
+	}
+
+	overrides, err := h.getOverrides()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := h.getChartValues(overrides)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(got) != "map[image:map[tag:2.0] replicas:1]" {
+		t.Errorf("getChartValues() = %v, want overridden image tag", got)
+	}
+
+	// values are rendered without changing the overrides reported by the status
+	if h.overrides != nil {
+		t.Errorf("getChartValues() set overrides %v", h.overrides)
+	}
+
+	if err := h.loadOverrides(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(h.overrides, overrides) {
+		t.Errorf("loadOverrides() = %v, want %v", h.overrides, overrides)
+	}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	pluginDir     = flag.String("plugin-dir", "/usr/local/lib/dummycd/plugins", "directory with plugin provider binaries")
+	pluginTimeout = flag.Duration("plugin-timeout", 120*time.Second, "timeout of plugin provider run")
+)
+
+type PluginEnv struct {
+	Name  string
+	Value string
+}
+
+// PluginProvider delivers objects generated by a binary from the plugin directory with RawProvider apply and prune semantics,
+// the binary runs in the application sparse path and writes yaml manifests to stdout
+type PluginProvider struct {
+	// Name of the binary in the plugin directory
+	Name      string
+	Args      []string
+	Env       []PluginEnv
+	raw       *RawProvider
+	appName   *string
+	sources   *string
+	namespace *string
+	revision  *plumbing.Hash
+	ctx       context.Context
+}
+
+// GetPluginPath returns path of the plugin binary, plugin name can't point outside the plugin directory
+func GetPluginPath(name string) (string, error) {
+	if len(name) == 0 || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: %q", util.ErrPluginNotFound, name)
+	}
+
+	pluginPath := filepath.Join(*pluginDir, name)
+
+	info, err := os.Stat(pluginPath)
+
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return "", fmt.Errorf("%w: %s", util.ErrPluginNotFound, pluginPath)
+	}
+
+	return pluginPath, nil
+}
+
+// NewPluginResources runs the plugin in the directory and returns resources of its stdout
+func NewPluginResources(ctx context.Context, name string, args []string, dir string, env []string) ([]*Resource, error) {
+	pluginPath, err := GetPluginPath(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *pluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, pluginPath, args...)
+
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return NewManifestResources(stdout.Bytes(), name)
+}
+
+func NewPluginProvider(ctx context.Context, appName *string, sourcePath *string, namespace *string,
+	plugin *PluginProvider, raw *RawProvider, restKubeConfig *rest.Config, appRevision *plumbing.Hash) (*PluginProvider, error) {
+
+	var err error
+
+	plugin.appName = appName
+	plugin.sources = sourcePath
+	plugin.namespace = namespace
+	plugin.revision = appRevision
+	plugin.ctx = ctx
+
+	raw.render = plugin.render
+
+	plugin.raw, err = NewRawProvider(ctx, appName, &[]string{*sourcePath}, nil, namespace, raw, restKubeConfig, appRevision)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin, nil
+}
+
+// getEnv returns environment of the plugin run, server environment is not passed besides PATH and HOME
+func (p *PluginProvider) getEnv() []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + os.Getenv("HOME"),
+		"DUMMYCD_APP_NAME=" + *p.appName,
+		"DUMMYCD_APP_NAMESPACE=" + *p.namespace,
+		"DUMMYCD_APP_REVISION=" + p.revision.String(),
+	}
+
+	for _, e := range p.Env {
+		env = append(env, e.Name+"="+e.Value)
+	}
+
+	return env
+}
+
+func (p *PluginProvider) render() ([]*Resource, error) {
+	return NewPluginResources(p.ctx, p.Name, p.Args, *p.sources, p.getEnv())
+}
+
+func (p *PluginProvider) Delivery() error {
+	return p.raw.Delivery()
+}
+
+func (p *PluginProvider) Uninstall() error {
+	return p.raw.Uninstall()
+}
+
+func (p *PluginProvider) Render() ([]*unstructured.Unstructured, error) {
+	return p.raw.Render()
+}
+
+func (p *PluginProvider) Diff() ([]*ResourceDiff, error) {
+	return p.raw.Diff()
+}
+
+func (p *PluginProvider) Health() (*ApplicationHealth, error) {
+	return p.raw.Health()
+}
+
+func (p *PluginProvider) Status() *ApplicationSync {
+	return p.raw.Status()
+}
+
+func (p *PluginProvider) ConfirmPrune() error {
+	return p.raw.ConfirmPrune()
+}
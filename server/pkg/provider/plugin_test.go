@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setTestPluginDir sets the plugin directory to temporary one with the executable scripts
+func setTestPluginDir(t *testing.T, scripts map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "not-executable"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := *pluginDir
+	*pluginDir = dir
+
+	t.Cleanup(func() { *pluginDir = previous })
+}
+
+func TestGetPluginPath(t *testing.T) {
+	setTestPluginDir(t, map[string]string{"generate": "exit 0\n"})
+
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{"generate", nil},
+		{"", util.ErrPluginNotFound},
+		{"missing", util.ErrPluginNotFound},
+		{"not-executable", util.ErrPluginNotFound},
+		{"../generate", util.ErrPluginNotFound},
+		{"/bin/sh", util.ErrPluginNotFound},
+		{"..", util.ErrPluginNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GetPluginPath(tt.name); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPluginPath(%q) error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPluginResources(t *testing.T) {
+	setTestPluginDir(t, map[string]string{
+		"generate": "printf 'apiVersion: v1\\nkind: ConfigMap\\nmetadata: {name: %s}\\ndata: {dir: %s, secret: \"%s\"}\\n' \"$1\" \"$(basename \"$PWD\")\" \"$SECRET\"\n",
+		"fail":     "echo broken >&2\nexit 1\n",
+	})
+
+	dir := filepath.Join(t.TempDir(), "app")
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SECRET", "server")
+
+	resources, err := NewPluginResources(context.Background(), "generate", []string{"config"}, dir, []string{"PATH=" + os.Getenv("PATH")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resources) != 1 {
+		t.Fatalf("NewPluginResources() returned %d resources, want 1", len(resources))
+	}
+
+	data := fmt.Sprint(resources[0].obj.Object["data"])
+
+	if name := resources[0].obj.GetName(); name != "config" || data != "map[dir:app secret:]" {
+		t.Errorf("NewPluginResources() = %s %s, want config map[dir:app secret:]", name, data)
+	}
+
+	if _, err := NewPluginResources(context.Background(), "fail", nil, dir, nil); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("NewPluginResources() of failed plugin error = %v, want stderr of the plugin", err)
+	}
+}
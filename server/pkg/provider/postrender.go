@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"helm.sh/helm/v3/pkg/postrender"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+// helmOutputFile is the file of the rendered manifest added to resources of the post-render kustomization
+const helmOutputFile = "helm-output.yaml"
+
+// HelmPostRender changes the rendered manifest of the release before it is installed, upgraded or rendered
+type HelmPostRender struct {
+	// Kustomize is path of the kustomization directory relative to the chart path,
+	// the rendered manifest is added to its resources as helm-output.yaml
+	Kustomize string
+	// Plugin is name of the binary in the plugin directory, it reads the rendered manifest from stdin and writes yaml manifests to stdout
+	Plugin string
+	Args   []string
+}
+
+// kustomizePostRenderer builds the kustomization in-process from in-memory copy of its directory
+type kustomizePostRenderer struct {
+	kustomizationPath string
+}
+
+// pluginPostRenderer runs the plugin binary in the chart path
+type pluginPostRenderer struct {
+	name      string
+	args      []string
+	chartPath string
+	env       []string
+}
+
+// newPostRenderer returns post-renderer of the release, nil if post-render is not set
+func (h *HelmProvider) newPostRenderer() (postrender.PostRenderer, error) {
+	postRender := h.PostRender
+	chartPath := *h.chartPath
+
+	switch {
+	case len(postRender.Kustomize) > 0 && len(postRender.Plugin) > 0:
+		return nil, fmt.Errorf("%w: kustomize and plugin post-render are exclusive", util.ErrInvalidPostRender)
+	case len(postRender.Kustomize) > 0:
+		kustomizationPath := filepath.Join(chartPath, postRender.Kustomize)
+
+		if !IsKustomizationDir(kustomizationPath) {
+			return nil, fmt.Errorf("%w: %s is not a kustomization directory", util.ErrInvalidPostRender, postRender.Kustomize)
+		}
+
+		return &kustomizePostRenderer{kustomizationPath: kustomizationPath}, nil
+	case len(postRender.Plugin) > 0:
+		if _, err := GetPluginPath(postRender.Plugin); err != nil {
+			return nil, err
+		}
+
+		// server environment is not passed besides PATH and HOME like to plugin providers
+		env := []string{
+			"PATH=" + os.Getenv("PATH"),
+			"HOME=" + os.Getenv("HOME"),
+			"DUMMYCD_APP_NAME=" + *h.releaseName,
+			"DUMMYCD_APP_NAMESPACE=" + *h.namespace,
+			"DUMMYCD_APP_REVISION=" + h.appRevision.String(),
+		}
+
+		return &pluginPostRenderer{name: postRender.Plugin, args: postRender.Args, chartPath: chartPath, env: env}, nil
+	}
+
+	return nil, nil
+}
+
+func (k *kustomizePostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	fileSystem := filesys.MakeFsInMemory()
+
+	err := filepath.WalkDir(k.kustomizationPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(filePath)
+
+		if err != nil {
+			return err
+		}
+
+		return fileSystem.WriteFile(filePath, data)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addKustomizationResource(fileSystem, k.kustomizationPath, helmOutputFile); err != nil {
+		return nil, err
+	}
+
+	if err := fileSystem.WriteFile(filepath.Join(k.kustomizationPath, helmOutputFile), renderedManifests.Bytes()); err != nil {
+		return nil, err
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fileSystem, k.kustomizationPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("kustomize post-render: %w", err)
+	}
+
+	manifests, err := resMap.AsYaml()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewBuffer(manifests), nil
+}
+
+// addKustomizationResource adds the resource to the kustomization file of the directory if it is not there
+func addKustomizationResource(fileSystem filesys.FileSystem, kustomizationPath string, resource string) error {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		kustomizationFile := filepath.Join(kustomizationPath, fileName)
+
+		if !fileSystem.Exists(kustomizationFile) {
+			continue
+		}
+
+		data, err := fileSystem.ReadFile(kustomizationFile)
+
+		if err != nil {
+			return err
+		}
+
+		kustomization := &types.Kustomization{}
+
+		if err := yaml.Unmarshal(data, kustomization); err != nil {
+			return fmt.Errorf("%s: %w", kustomizationFile, err)
+		}
+
+		if util.ContainsString(kustomization.Resources, resource) {
+			return nil
+		}
+
+		kustomization.Resources = append(kustomization.Resources, resource)
+
+		data, err = yaml.Marshal(kustomization)
+
+		if err != nil {
+			return err
+		}
+
+		return fileSystem.WriteFile(kustomizationFile, data)
+	}
+
+	return fmt.Errorf("%w: kustomization file not found in %s", util.ErrInvalidPostRender, kustomizationPath)
+}
+
+func (p *pluginPostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	pluginPath, err := GetPluginPath(p.name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *pluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, pluginPath, p.args...)
+
+	cmd.Dir = p.chartPath
+	cmd.Env = p.env
+	cmd.Stdin = renderedManifests
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("post-render plugin %s: %w: %s", p.name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return &stdout, nil
+}
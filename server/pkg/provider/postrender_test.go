@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+	"strings"
+	"testing"
+)
+
+const testRenderedManifest = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"
+
+func TestAddKustomizationResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "added",
+			files: map[string]string{"/post/kustomization.yaml": "resources: [patch.yaml]\n"},
+			want:  []string{"patch.yaml", helmOutputFile},
+		},
+		{
+			name:  "already added",
+			files: map[string]string{"/post/Kustomization": "resources: [" + helmOutputFile + "]\n"},
+			want:  []string{helmOutputFile},
+		},
+		{
+			name:    "kustomization not found",
+			files:   map[string]string{"/post/patch.yaml": "{}\n"},
+			wantErr: util.ErrInvalidPostRender,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileSystem := filesys.MakeFsInMemory()
+
+			var kustomizationFile string
+
+			for name, content := range tt.files {
+				if err := fileSystem.WriteFile(name, []byte(content)); err != nil {
+					t.Fatal(err)
+				}
+
+				kustomizationFile = name
+			}
+
+			err := addKustomizationResource(fileSystem, "/post", helmOutputFile)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("addKustomizationResource() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			data, err := fileSystem.ReadFile(kustomizationFile)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kustomization := &types.Kustomization{}
+
+			if err := yaml.Unmarshal(data, kustomization); err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Join(kustomization.Resources, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("addKustomizationResource() resources = %v, want %v", kustomization.Resources, tt.want)
+			}
+		})
+	}
+}
+
+func TestKustomizePostRendererRun(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{"post/kustomization.yaml": "namePrefix: prod-\n"})
+
+	renderer := &kustomizePostRenderer{kustomizationPath: filepath.Join(root, "post")}
+
+	got, err := renderer.Run(bytes.NewBufferString(testRenderedManifest))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(got.String(), "name: prod-config") {
+		t.Errorf("Run() = %s, want name prefix applied", got.String())
+	}
+
+	// the kustomization is built from in-memory copy, so the directory is left unchanged
+	data, err := os.ReadFile(filepath.Join(root, "post", "kustomization.yaml"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), helmOutputFile) {
+		t.Errorf("Run() changed the kustomization file of the chart: %s", data)
+	}
+}
+
+func TestPluginPostRendererRun(t *testing.T) {
+	setTestPluginDir(t, map[string]string{
+		"label": "sed \"s/name: config/name: config-$DUMMYCD_APP_NAME/\"\n",
+		"fail":  "echo broken >&2\nexit 1\n",
+	})
+
+	tests := []struct {
+		name    string
+		plugin  string
+		want    string
+		wantErr bool
+	}{
+		{name: "output", plugin: "label", want: "name: config-app"},
+		{name: "failed", plugin: "fail", wantErr: true},
+		{name: "not found", plugin: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := &pluginPostRenderer{name: tt.plugin, chartPath: t.TempDir(), env: []string{"DUMMYCD_APP_NAME=app"}}
+
+			got, err := renderer.Run(bytes.NewBufferString(testRenderedManifest))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !strings.Contains(got.String(), tt.want) {
+				t.Errorf("Run() = %s, want %s", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPostRenderer(t *testing.T) {
+	setTestPluginDir(t, map[string]string{"label": "cat\n"})
+
+	chartPath := t.TempDir()
+
+	writeTestFiles(t, chartPath, map[string]string{"post/kustomization.yaml": "namePrefix: prod-\n", "templates/config.yaml": "{}\n"})
+
+	tests := []struct {
+		name       string
+		postRender HelmPostRender
+		wantNil    bool
+		wantErr    error
+	}{
+		{name: "not set", wantNil: true},
+		{name: "kustomize", postRender: HelmPostRender{Kustomize: "post"}},
+		{name: "plugin", postRender: HelmPostRender{Plugin: "label"}},
+		{name: "exclusive", postRender: HelmPostRender{Kustomize: "post", Plugin: "label"}, wantErr: util.ErrInvalidPostRender},
+		{name: "not kustomization", postRender: HelmPostRender{Kustomize: "templates"}, wantErr: util.ErrInvalidPostRender},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releaseName, namespace := "app", "default"
+
+			h := &HelmProvider{
+				PostRender:  tt.postRender,
+				chartPath:   &chartPath,
+				releaseName: &releaseName,
+				namespace:   &namespace,
+				appRevision: &plumbing.ZeroHash,
+			}
+
+			got, err := h.newPostRenderer()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newPostRenderer() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && (got == nil) != tt.wantNil {
+				t.Errorf("newPostRenderer() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
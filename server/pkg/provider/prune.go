@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"sort"
+)
+
+const (
+	PruneEnabled  = "enabled"
+	PruneDisabled = "disabled"
+	PruneConfirm  = "confirm"
+
+	// PruneAnnotation set to "false" protects the object from deletion by prune and uninstall
+	PruneAnnotation = "dummy.cd/prune"
+)
+
+// PruneConfirmer is implemented by providers which can hold prunes until they are confirmed
+type PruneConfirmer interface {
+	// ConfirmPrune allows the next cleanup to delete the objects pending prune
+	ConfirmPrune() error
+}
+
+// getPruneBlock returns reason why the objects are not deleted by the prune policy, empty if they can be deleted
+func (r *RawProvider) getPruneBlock(prune int, total int) string {
+	switch r.ActionOptions.Prune {
+	case PruneDisabled:
+		return "prune is disabled"
+	case PruneConfirm:
+		return "waiting for prune confirmation"
+	}
+
+	if limit := r.ActionOptions.PruneLimitPercent; limit > 0 && total > 0 && prune*100 > limit*total {
+		return fmt.Sprintf("%d of %d objects would be pruned, the limit is %d%%", prune, total, limit)
+	}
+
+	return ""
+}
+
+// setPendingPrunes records the objects removed from the sources and not deleted, they are reported by Status
+func (r *RawProvider) setPendingPrunes(pending map[string]*ResourceDiff) {
+	r.statusMutex.Lock()
+	defer r.statusMutex.Unlock()
+
+	r.pendingPrunes = pending
+}
+
+// takeConfirmedPrunes returns keys of the objects confirmed to be pruned and resets the confirmation
+func (r *RawProvider) takeConfirmedPrunes() map[string]bool {
+	r.statusMutex.Lock()
+	defer r.statusMutex.Unlock()
+
+	confirmed := r.confirmedPrunes
+	r.confirmedPrunes = nil
+
+	return confirmed
+}
+
+// ConfirmPrune confirms deletion of the objects currently pending prune, objects which become pending later
+// have to be confirmed again, disabled prune and protected objects are never deleted
+func (r *RawProvider) ConfirmPrune() error {
+	r.statusMutex.Lock()
+	defer r.statusMutex.Unlock()
+
+	if len(r.pendingPrunes) == 0 {
+		return util.ErrNoPendingPrunes
+	}
+
+	r.confirmedPrunes = make(map[string]bool)
+
+	for key := range r.pendingPrunes {
+		r.confirmedPrunes[key] = true
+	}
+
+	r.logWithFields().Infof("prune of %d objects confirmed", len(r.confirmedPrunes))
+
+	return nil
+}
+
+// getPendingPrunes returns the objects pending prune sorted by kind, namespace and name
+func (r *RawProvider) getPendingPrunes() []*ResourceDiff {
+	var prunes []*ResourceDiff
+
+	for _, prune := range r.pendingPrunes {
+		prunes = append(prunes, prune)
+	}
+
+	sort.Slice(prunes, func(i, j int) bool {
+		if prunes[i].Kind != prunes[j].Kind {
+			return prunes[i].Kind < prunes[j].Kind
+		}
+
+		if prunes[i].Namespace != prunes[j].Namespace {
+			return prunes[i].Namespace < prunes[j].Namespace
+		}
+
+		return prunes[i].Name < prunes[j].Name
+	})
+
+	return prunes
+}
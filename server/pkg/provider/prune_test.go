@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"testing"
+)
+
+func TestGetPruneBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RawActionOptions
+		prune   int
+		total   int
+		blocked bool
+	}{
+		{name: "enabled", options: RawActionOptions{Prune: PruneEnabled}, prune: 5, total: 5},
+		{name: "default", prune: 1, total: 1},
+		{name: "disabled", options: RawActionOptions{Prune: PruneDisabled}, prune: 1, total: 10, blocked: true},
+		{name: "confirm", options: RawActionOptions{Prune: PruneConfirm}, prune: 1, total: 10, blocked: true},
+		{name: "under limit", options: RawActionOptions{PruneLimitPercent: 20}, prune: 2, total: 10},
+		{name: "over limit", options: RawActionOptions{PruneLimitPercent: 20}, prune: 3, total: 10, blocked: true},
+		{name: "limit of empty application", options: RawActionOptions{PruneLimitPercent: 20}, prune: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newTestRawProvider(tt.options).getPruneBlock(tt.prune, tt.total)
+
+			if (len(got) > 0) != tt.blocked {
+				t.Errorf("getPruneBlock(%d, %d) = %q, blocked %v", tt.prune, tt.total, got, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestConfirmPrune(t *testing.T) {
+	r := newTestRawProvider(RawActionOptions{Prune: PruneConfirm})
+
+	if err := r.ConfirmPrune(); !errors.Is(err, util.ErrNoPendingPrunes) {
+		t.Fatalf("ConfirmPrune() without pending prunes error = %v, want %v", err, util.ErrNoPendingPrunes)
+	}
+
+	r.setPendingPrunes(map[string]*ResourceDiff{"a": {Kind: "ConfigMap", Name: "a"}, "b": {Kind: "Secret", Name: "b"}})
+
+	if err := r.ConfirmPrune(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.takeConfirmedPrunes(); len(got) != 2 || !got["a"] || !got["b"] {
+		t.Errorf("takeConfirmedPrunes() = %v, want a and b", got)
+	}
+
+	if got := r.takeConfirmedPrunes(); got != nil {
+		t.Errorf("confirmation is not reset: %v", got)
+	}
+}
+
+func TestGetPendingPrunes(t *testing.T) {
+	r := newTestRawProvider(RawActionOptions{})
+
+	r.setPendingPrunes(map[string]*ResourceDiff{
+		"1": {Kind: "Secret", Namespace: "a", Name: "a"},
+		"2": {Kind: "ConfigMap", Namespace: "b", Name: "a"},
+		"3": {Kind: "ConfigMap", Namespace: "a", Name: "b"},
+		"4": {Kind: "ConfigMap", Namespace: "a", Name: "a"},
+	})
+
+	var got []string
+
+	for _, prune := range r.getPendingPrunes() {
+		got = append(got, fmt.Sprintf("%s/%s/%s", prune.Kind, prune.Namespace, prune.Name))
+	}
+
+	want := []string{"ConfigMap/a/a", "ConfigMap/a/b", "ConfigMap/b/a", "Secret/a/a"}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("getPendingPrunes() = %v, want %v", got, want)
+	}
+}
@@ -3,106 +3,199 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/go-git/go-git/v5/plumbing"
 	log "github.com/sirupsen/logrus"
 	"github.com/yimgzz/dummy-cd/server/pkg/util"
-	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"os"
 	"strings"
 	"sync"
+	"time"
 )
 
+// FieldManager is the server-side apply field manager of raw resources
+const FieldManager = "dummycd"
+
 type Resource struct {
-	group    string
-	version  string
-	resource string
-	filePath string
-	obj      *unstructured.Unstructured
+	gvr        schema.GroupVersionResource
+	namespaced bool
+	namespace  string
+	filePath   string
+	document   int
+	obj        *unstructured.Unstructured
+}
+
+func (r *Resource) String() string {
+	return fmt.Sprintf("%s/%s %s (%s, document %d)", r.obj.GetAPIVersion(), r.obj.GetKind(), r.obj.GetName(), r.filePath, r.document)
+}
+
+type RawActionOptions struct {
+	RecurseDepth int
+	// ForceConflicts takes ownership of fields managed by other field managers, conflicts are reported otherwise
+	ForceConflicts bool
+	// AllowedNamespaces besides the application namespace, "*" allows any namespace
+	AllowedNamespaces []string
+	// AllowedClusterResources are cluster-scoped kinds as "Kind" or "group/Kind", "*" allows any kind
+	AllowedClusterResources []string
+	// SelfHeal re-applies objects drifted from the git state of the delivered revision
+	SelfHeal bool
+	// WaitHealthy fails delivery unless all resources become healthy within HealthTimeout, health-timeout flag is used if it is zero
+	WaitHealthy   bool
+	HealthTimeout time.Duration
+	// Prune is enabled, disabled or confirm, objects removed from the sources are deleted only after ConfirmPrune in confirm mode
+	Prune string
+	// PruneLimitPercent holds prune pending confirmation when more percent of the application objects would be deleted, 0 is unlimited
+	PruneLimitPercent int
+	// AllowAdoption takes over existing objects created outside of dummycd or by another application
+	AllowAdoption bool
+	// Replace deletes and creates again objects rejected because of changed immutable fields,
+	// ReplaceWait waits for the object and its dependents to be deleted first
+	Replace     bool
+	ReplaceWait bool
+	// Atomic waits for the resources to become healthy and rolls back to the last known-good revision if the sync fails
+	Atomic bool
+	// Validate checks all objects with server-side dry-run and aborts the sync before any write if one of them fails
+	Validate bool
 }
 
 type RawProvider struct {
+	ActionOptions *RawActionOptions
 	appName       *string
-	resourcePath  *string
+	resourcePaths *[]string
+	fileFilter    *util.FileFilter
 	namespace     *string
 	resourceFiles *[]string
 	appRevision   *plumbing.Hash
 	dynamicClient *dynamic.DynamicClient
 	clientSet     *kubernetes.Clientset
+	mapper        *ResourceMapper
 	resources     []*Resource
+	render        func() ([]*Resource, error)
 	labels        map[string]string
 	mutex         *sync.Mutex
 	ctx           context.Context
 	kubeConfig    *rest.Config
+	drifts        []*ResourceDiff
+	sync          *ApplicationSync
+	statusMutex   *sync.Mutex
+	ignoreRules   []*ignoreRule
+	// inventoryMutex serializes read-modify-write of the inventory by delivery and cleanup
+	inventoryMutex  *sync.Mutex
+	pendingPrunes   map[string]*ResourceDiff
+	confirmedPrunes map[string]bool
+	history         syncHistory
 }
 
-func NewUnstructuredResource(path string) *Resource {
-	resource := make(map[string]interface{})
+// NewUnstructuredResource returns resource of the object from document of the file
+func NewUnstructuredResource(obj *unstructured.Unstructured, path string, document int) (*Resource, error) {
+	if len(obj.GetAPIVersion()) == 0 || len(obj.GetKind()) == 0 || len(obj.GetName()) == 0 {
+		return nil, fmt.Errorf("%s: document %d: %w: apiVersion, kind and metadata.name are required", path, document, util.ErrInvalidResource)
+	}
+
+	return &Resource{
+		filePath: path,
+		document: document,
+		obj:      obj,
+	}, nil
 
-	file := util.ReadFile(&path)
+}
 
-	err := yaml.Unmarshal(*file, &resource)
+// resolve sets GroupVersionResource, scope and target namespace of the resource from RESTMapper,
+// namespaced resources without metadata.namespace are delivered to the default namespace
+func (r *Resource) resolve(mapper *ResourceMapper, defaultNamespace string) error {
+	mapping, err := mapper.RESTMapping(r.obj.GroupVersionKind())
 
 	if err != nil {
-		log.Error(err)
-		return nil
+		return err
 	}
 
-	if len(resource) == 0 {
-		log.Errorf("empty resource found, skipping %s", path)
-		return nil
+	r.gvr = mapping.Resource
+	r.namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+
+	if r.namespaced {
+		r.namespace = r.obj.GetNamespace()
+
+		if len(r.namespace) == 0 {
+			r.namespace = defaultNamespace
+		}
+	} else {
+		r.namespace = ""
 	}
 
-	obj := &unstructured.Unstructured{Object: resource}
+	r.obj.SetNamespace(r.namespace)
 
-	apiVersionTokens := strings.Split(obj.GetAPIVersion(), "/")
+	return nil
+}
 
-	var version string
-	var group string
-	if len(apiVersionTokens) == 1 {
-		version = apiVersionTokens[0]
-		group = ""
-	} else {
-		group = apiVersionTokens[0]
-		version = apiVersionTokens[1]
+// NewUnstructuredResourcesFromFile returns resources of all yaml documents or json objects in the file
+func NewUnstructuredResourcesFromFile(path string) ([]*Resource, error) {
+	var resources []*Resource
+	var errs []error
+
+	file, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return &Resource{
-		filePath: path,
-		group:    group,
-		version:  version,
-		resource: strings.ToLower(obj.GetKind() + "s"),
-		obj:      obj,
+	documents, err := SplitYAMLDocuments(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
 
+	// invalid documents are reported together, so all of them are fixed at once
+	for document, data := range documents {
+		documentResources, err := NewDocumentResources(data, path, document)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		resources = append(resources, documentResources...)
+	}
+
+	if len(resources) == 0 && len(errs) == 0 {
+		log.Warnf("empty resource file found, skipping %s", path)
+	}
+
+	return resources, errors.Join(errs...)
 }
 
-func NewUnstructuredResources(files []string) []*Resource {
+// NewUnstructuredResources returns resources of all files, errors of unreadable or invalid files are joined
+func NewUnstructuredResources(files []string) ([]*Resource, error) {
 	var resources []*Resource
+	var errs []error
 
 	for _, path := range files {
-		resource := NewUnstructuredResource(path)
+		fileResources, err := NewUnstructuredResourcesFromFile(path)
 
-		if resource == nil {
+		if err != nil {
+			errs = append(errs, err)
 			continue
 		}
 
-		resources = append(resources, resource)
+		resources = append(resources, fileResources...)
 	}
 
-	return resources
+	return resources, errors.Join(errs...)
 }
 
-func NewRawProvider(ctx context.Context, appName *string, resourcePath *string,
-	namespace *string, restKubeConfig *rest.Config, appRevision *plumbing.Hash) (*RawProvider, error) {
+func NewRawProvider(ctx context.Context, appName *string, resourcePaths *[]string, fileFilter *util.FileFilter,
+	namespace *string, raw *RawProvider, restKubeConfig *rest.Config, appRevision *plumbing.Hash) (*RawProvider, error) {
 
 	var err error
 
@@ -113,15 +206,18 @@ func NewRawProvider(ctx context.Context, appName *string, resourcePath *string,
 		ns = *namespace
 	}
 
-	rawProvider := RawProvider{
-		appName:      appName,
-		resourcePath: resourcePath,
-		namespace:    &ns,
-		appRevision:  appRevision,
-		mutex:        new(sync.Mutex),
-		ctx:          ctx,
-		kubeConfig:   restKubeConfig,
-	}
+	rawProvider := raw
+
+	rawProvider.appName = appName
+	rawProvider.resourcePaths = resourcePaths
+	rawProvider.fileFilter = fileFilter
+	rawProvider.namespace = &ns
+	rawProvider.appRevision = appRevision
+	rawProvider.mutex = new(sync.Mutex)
+	rawProvider.statusMutex = new(sync.Mutex)
+	rawProvider.inventoryMutex = new(sync.Mutex)
+	rawProvider.ctx = ctx
+	rawProvider.kubeConfig = restKubeConfig
 
 	rawProvider.clientSet, err = kubernetes.NewForConfig(restKubeConfig)
 
@@ -154,210 +250,487 @@ func NewRawProvider(ctx context.Context, appName *string, resourcePath *string,
 		return nil, err
 	}
 
-	resourceFiles, err := rawProvider.getResourceFiles()
+	rawProvider.mapper, err = NewResourceMapper(restKubeConfig)
 
 	if err != nil {
+		rawProvider.logWithFields().Error(err)
 		return nil, err
 	}
 
-	if len(resourceFiles) == 0 {
-		return nil, errors.New("no one resource file found")
+	if rawProvider.render == nil {
+		rawProvider.render = rawProvider.getFileResources
 	}
 
-	rawProvider.resources = NewUnstructuredResources(resourceFiles)
+	rawProvider.resources, err = rawProvider.render()
 
-	//if len(rawProvider.resources) == 0 {
-	//	return nil, errors.New("no one resource appended")
-	//}
+	if err != nil {
+		rawProvider.logWithFields().Error(err)
+		return nil, err
+	}
 
 	rawProvider.labels = map[string]string{
 		"dummy.cd/app": *appName,
 	}
 
-	return &rawProvider, nil
+	return rawProvider, nil
 }
 
 func (r *RawProvider) logWithFields() *log.Entry {
 	return log.WithFields(log.Fields{"app": *r.appName, "revision": r.appRevision.String()})
 }
 
+// getFileResources returns resources of manifest files found in resource paths
+func (r *RawProvider) getFileResources() ([]*Resource, error) {
+	resourceFiles, err := r.getResourceFiles()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resourceFiles) == 0 {
+		return nil, util.ErrNoResourceFiles
+	}
+
+	return NewUnstructuredResources(resourceFiles)
+}
+
 func (r *RawProvider) getResourceFiles() ([]string, error) {
-	resourceFiles := util.FindFilesWithRegex(r.resourcePath, "^.+\\.(yaml|yml)$")
+	var resourceFiles []string
 
-	//if len(resourceFiles) == 0 {
-	//	return nil, errors.New("no one resource appended")
-	//}
+	for _, resourcePath := range *r.resourcePaths {
+		var matchedFiles []string
 
-	resourceFiles = util.GetFilesFullPath(r.resourcePath, &resourceFiles)
+		files, err := util.FindFilesWithRegex(&resourcePath, "^.+\\.(yaml|yml|json)$", r.ActionOptions.RecurseDepth)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if r.fileFilter.Match(file) {
+				matchedFiles = append(matchedFiles, file)
+			}
+		}
+
+		resourceFiles = append(resourceFiles, util.GetFilesFullPath(&resourcePath, &matchedFiles)...)
+	}
 
 	return resourceFiles, nil
 }
 
-func (r *RawProvider) cleanResources(cleanAll bool) error {
+// cleanResources deletes objects of the inventory which are not in the current resources, nil removes all objects on uninstall.
+// Objects already removed or relabelled to another application are only dropped from the inventory, objects protected
+// by the prune annotation are never deleted, prune policy and limit hold the objects pending until they are confirmed
+func (r *RawProvider) cleanResources(current []*Resource) error {
 	defer r.mutex.Unlock()
 
 	if r.appRevision.IsZero() {
 		return nil
 	}
 
-	apiGroupList, err := r.clientSet.ServerGroups()
+	uninstall := current == nil
+
+	r.inventoryMutex.Lock()
+	inventory, err := r.getInventory()
+	r.inventoryMutex.Unlock()
 
 	if err != nil {
 		r.logWithFields().Error(err)
 		return err
 	}
 
-	labelAppNameRequirement, err := labels.NewRequirement("dummy.cd/app", selection.Equals, []string{*r.appName})
+	total := len(inventory)
 
-	if err != nil {
-		r.logWithFields().Debug(err)
-		return err
+	for _, resource := range current {
+		delete(inventory, newInventoryEntry(resource).key())
 	}
 
-	labelsSelector := labels.NewSelector()
-	labelsSelector = labelsSelector.Add(*labelAppNameRequirement)
+	pending := make(map[string]*ResourceDiff)
 
-	if !cleanAll {
-		labelAppRevisionRequirement, err := labels.NewRequirement("dummy.cd/revision", selection.NotEquals, []string{r.appRevision.String()})
+	var prune []*Resource
+	var forget []*Resource
+
+	for key, entry := range inventory {
+		resource := entry.newResource()
+
+		live, err := getLiveObject(r.ctx, r.dynamicClient, resource)
 
 		if err != nil {
-			r.logWithFields().Debug(err)
-			return err
+			r.logWithFields().Errorf("%s: %s", err, resource)
+			continue
+		}
+
+		if live == nil {
+			forget = append(forget, resource)
+			continue
+		}
+
+		if live.GetLabels()["dummy.cd/app"] != *r.appName {
+			r.logWithFields().Warnf("%s is not labelled with the application anymore, skip pruning", resource)
+			forget = append(forget, resource)
+			continue
 		}
 
-		labelsSelector = labelsSelector.Add(*labelAppRevisionRequirement)
+		resource.obj = live
+
+		if live.GetAnnotations()[PruneAnnotation] == "false" {
+			r.logWithFields().Infof("%s is protected by %s annotation, skip pruning", resource, PruneAnnotation)
+
+			if uninstall {
+				forget = append(forget, resource)
+			} else {
+				pending[key] = newResourceDiff(resource, SyncStatusOutOfSync, fmt.Sprintf("protected by %s annotation", PruneAnnotation))
+			}
+
+			continue
+		}
+
+		prune = append(prune, resource)
 	}
 
-	for _, apiGroup := range apiGroupList.Groups {
-		for _, apiGroupVersion := range apiGroup.Versions {
-			apiResourceList, err := r.clientSet.ServerResourcesForGroupVersion(apiGroupVersion.GroupVersion)
+	if !uninstall {
+		confirmed := r.takeConfirmedPrunes()
+		block := r.getPruneBlock(len(prune), total)
 
-			if err != nil {
-				r.logWithFields().Debug(err)
+		var allowed []*Resource
+
+		for _, resource := range prune {
+			key := newInventoryEntry(resource).key()
+
+			if len(block) == 0 || (confirmed[key] && r.ActionOptions.Prune != PruneDisabled) {
+				allowed = append(allowed, resource)
 				continue
 			}
 
-			blocker := make(chan struct{}, 10)
+			pending[key] = newResourceDiff(resource, SyncStatusOutOfSync, fmt.Sprintf("requires pruning: %s", block))
+		}
 
-			for _, apiResource := range apiResourceList.APIResources {
+		if len(prune) > len(allowed) {
+			r.logWithFields().Warnf("%d objects are not pruned: %s", len(prune)-len(allowed), block)
+		}
 
-				blocker <- struct{}{}
-				go func(apiGroupName string, apiGroupVersion string, apiResourceName string) {
+		prune = allowed
+	}
 
-					r.logWithFields().Tracef("run cleanup task on %s: %s", apiGroupVersion, apiResourceName)
+	deleted := r.deleteResources(prune)
 
-					remoteResourceList, err := r.dynamicClient.Resource(schema.GroupVersionResource{
-						Group:    apiGroupName,
-						Version:  apiGroupVersion,
-						Resource: apiResourceName,
-					}).Namespace(*r.namespace).List(r.ctx, metav1.ListOptions{
-						LabelSelector: labelsSelector.String(),
-					})
+	if len(deleted) < len(prune) {
+		deletedKeys := make(map[string]bool)
 
-					if err != nil {
-						r.logWithFields().Tracef("%s: %s: %s", apiGroupVersion, apiResourceName, err)
-						<-blocker
-						return
-					}
+		for _, resource := range deleted {
+			deletedKeys[newInventoryEntry(resource).key()] = true
+		}
+
+		for _, resource := range prune {
+			if key := newInventoryEntry(resource).key(); !deletedKeys[key] {
+				pending[key] = newResourceDiff(resource, SyncStatusOutOfSync, "requires pruning: delete failed")
+			}
+		}
+	}
+
+	r.setPendingPrunes(pending)
+
+	if err := r.updateInventory(nil, append(forget, deleted...)); err != nil {
+		r.logWithFields().Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// deleteResources deletes the resources in reverse sync wave and kind order,
+// every wave is waited to be removed before the next one, the deleted resources are returned
+func (r *RawProvider) deleteResources(resources []*Resource) []*Resource {
+	steps := newSyncSteps(resources, true)
+
+	var deleted []*Resource
+	var waveResources []*Resource
 
-					r.logWithFields().Tracef("done cleanup task on %s: %s", apiGroupVersion, apiResourceName)
+	for i, step := range steps {
+		for _, resource := range step.resources {
+			r.logWithFields().Debugf("deleting %s", resource.obj)
 
-					for _, remoteResource := range remoteResourceList.Items {
-						r.logWithFields().Debugf("deleting %s", remoteResource)
+			propagationPolicy := metav1.DeletePropagationForeground
+
+			err := r.resourceInterface(resource).Delete(r.ctx, resource.obj.GetName(), metav1.DeleteOptions{
+				PropagationPolicy: &propagationPolicy,
+			})
+
+			if err != nil && !k8sErrors.IsNotFound(err) {
+				r.logWithFields().Debugf("error: %s: %s", err, resource.obj)
+				continue
+			}
 
-						propagationPolicy := metav1.DeletePropagationForeground
+			r.logWithFields().Debugf("deleted %s", resource.obj)
 
-						err := r.dynamicClient.Resource(schema.GroupVersionResource{
-							Group:    apiGroupName,
-							Version:  apiGroupVersion,
-							Resource: apiResourceName,
-						}).Namespace(*r.namespace).Delete(r.ctx, remoteResource.GetName(), metav1.DeleteOptions{
-							PropagationPolicy: &propagationPolicy,
-						})
+			deleted = append(deleted, resource)
+			waveResources = append(waveResources, resource)
+		}
 
-						if err != nil {
-							r.logWithFields().Debugf("error: %s: %s", err, remoteResource)
-						} else {
-							r.logWithFields().Debugf("deleted %s", remoteResource)
-						}
-					}
-					<-blocker
-				}(apiGroup.Name, apiGroupVersion.Version, apiResource.Name)
+		if i+1 < len(steps) && steps[i+1].wave != step.wave {
+			if err := waitDeleted(r.ctx, r.dynamicClient, waveResources); err != nil {
+				r.logWithFields().Errorf("%s: sync wave %d", err, -step.wave)
 			}
+
+			waveResources = nil
 		}
 	}
 
-	return nil
+	return deleted
 }
 
-func (r *Resource) Delivery(p *RawProvider, wg *sync.WaitGroup) {
-	defer wg.Done()
+// Delivery applies the resource and returns its result, drift of the delivered revision is recorded to the provider status
+func (r *Resource) Delivery(p *RawProvider) *ResourceResult {
+	if err := r.resolve(p.mapper, *p.namespace); err != nil {
+		return p.failResource(r, err)
+	}
 
-	remoteResource, err := p.dynamicClient.Resource(schema.GroupVersionResource{
-		Group:    r.group,
-		Version:  r.version,
-		Resource: r.resource,
-	}).Namespace(*p.namespace).Get(p.ctx, r.obj.GetName(), metav1.GetOptions{})
+	if err := p.checkAllowed(r.obj.GroupVersionKind().GroupKind(), r.namespaced, r.namespace); err != nil {
+		return p.failResource(r, err)
+	}
 
-	r.obj.SetLabels(p.labels)
+	remoteResource, err := getLiveObject(p.ctx, p.dynamicClient, r)
 
 	if err != nil {
-		if k8sErrors.IsNotFound(err) {
-			_, err := p.dynamicClient.Resource(schema.GroupVersionResource{
-				Group:    r.group,
-				Version:  r.version,
-				Resource: r.resource,
-			}).Namespace(*p.namespace).Create(p.ctx, r.obj, metav1.CreateOptions{})
+		return p.failResource(r, err)
+	}
 
-			if err != nil {
-				p.logWithFields().Errorf("%s: %+v", err, r)
-				return
+	operation := ResourceOperationCreated
+
+	setTrackingLabels(r.obj, p.labels)
+
+	var drift *ResourceDiff
+	var adopt bool
+
+	if remoteResource != nil {
+		operation = ResourceOperationUpdated
+
+		remoteLabels := remoteResource.GetLabels()
+
+		if err := p.checkOwner(remoteResource); err != nil {
+			return p.failResource(r, err)
+		}
+
+		if remoteLabels["dummy.cd/app"] != *p.appName {
+			p.logWithFields().Infof("adopting %+v", r)
+			adopt = true
+		} else if p.labels["dummy.cd/revision"] == remoteLabels["dummy.cd/revision"] {
+			fields := DiffResource(r.obj, remoteResource, p.ignoreRules)
+
+			if len(fields) == 0 {
+				p.logWithFields().Debugf(
+					"revision already applied for %+v", r,
+				)
+				return newResourceResult(r, ResourceOperationUnchanged, "")
 			}
 
-			p.logWithFields().Debugf("resource created %+v", r)
-			return
-		} else {
-			p.logWithFields().Error(err)
-			return
+			drift = newResourceDiff(r, SyncStatusOutOfSync, "drifted from the git state")
+			drift.Fields = fields
+
+			if !p.ActionOptions.SelfHeal {
+				p.logWithFields().Infof("drift detected on %+v: %s", r, strings.Join(fields, ", "))
+				p.addDrift(drift)
+				return newResourceResult(r, ResourceOperationUnchanged, "drift detected, self-heal is disabled")
+			}
+
+			p.logWithFields().Infof("self-heal drift on %+v: %s", r, strings.Join(fields, ", "))
+		} else if err := p.upgradeManagedFields(r, remoteResource); err != nil {
+			return p.failResource(r, err)
 		}
 	}
 
-	remoteLabels := remoteResource.GetLabels()
+	obj := r.obj
+
+	if drift != nil {
+		obj = RespectIgnoredFields(r.obj, remoteResource, p.ignoreRules)
+	}
+
+	_, err = p.resourceInterface(r).Apply(p.ctx, r.obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        p.isForceApply(r.obj, remoteResource),
+	})
 
-	_, exist := remoteLabels["dummy.cd/revision"]
+	if err != nil && isImmutableFieldError(err) && p.isReplaceAllowed(r) {
+		p.logWithFields().Infof("replacing %+v: %s", r, err)
 
-	if !exist {
-		p.logWithFields().Errorf("label dummy.cd/revision not exist on %+v", r)
-		return
+		if err = p.replaceResource(r, obj); err == nil {
+			return newResourceResult(r, ResourceOperationReplaced, "immutable fields changed")
+		}
+
+		err = fmt.Errorf("replace failed: %w", err)
 	}
 
-	if p.labels["dummy.cd/revision"] != remoteLabels["dummy.cd/revision"] {
-		r.obj.SetResourceVersion(remoteResource.GetResourceVersion())
+	if err != nil {
+		if k8sErrors.IsConflict(err) {
+			err = fmt.Errorf("%w: fields are managed by another manager, enable forceConflicts to take ownership", err)
+		} else if isImmutableFieldError(err) {
+			err = fmt.Errorf("%w: enable replace or set %s annotation to recreate the object", err, ReplaceAnnotation)
+		}
 
-		_, err = p.dynamicClient.Resource(schema.GroupVersionResource{
-			Group:    r.group,
-			Version:  r.version,
-			Resource: r.resource,
-		}).Namespace(*p.namespace).
-			Update(p.ctx, r.obj, metav1.UpdateOptions{})
+		if drift != nil {
+			err = fmt.Errorf("self-heal failed: %w", err)
+		}
 
-		if err != nil {
-			p.logWithFields().Errorf("error while updating %+v", r)
+		return p.failResource(r, err)
+	}
+
+	p.logWithFields().Debugf("resource applied %+v", r)
+
+	if drift != nil {
+		return newResourceResult(r, operation, "drift self-healed")
+	}
+
+	if adopt {
+		return newResourceResult(r, operation, "adopted")
+	}
+
+	return newResourceResult(r, operation, "")
+}
+
+// isForceApply returns whether apply of the object takes fields changed by other managers, it is forced with forceConflicts,
+// for hooks, on adoption of the live object and on self-heal of the live object drifted from the delivered revision
+func (p *RawProvider) isForceApply(obj *unstructured.Unstructured, live *unstructured.Unstructured) bool {
+	switch {
+	case p.ActionOptions.ForceConflicts || IsHook(obj):
+		return true
+	case live == nil:
+		return false
+	case live.GetLabels()["dummy.cd/app"] != *p.appName:
+		return true
+	case !p.ActionOptions.SelfHeal || live.GetLabels()["dummy.cd/revision"] != p.labels["dummy.cd/revision"]:
+		return false
+	}
+
+	return len(DiffResource(obj, live, p.ignoreRules)) > 0
+}
+
+func newResourceResult(resource *Resource, operation ResourceOperation, message string) *ResourceResult {
+	return &ResourceResult{
+		APIVersion: resource.obj.GetAPIVersion(),
+		Kind:       resource.obj.GetKind(),
+		Namespace:  resource.namespace,
+		Name:       resource.obj.GetName(),
+		Operation:  operation,
+		Message:    message,
+	}
+}
+
+// failResource logs the error and records the resource out of sync, so the failed delivery is reported and retried
+func (r *RawProvider) failResource(resource *Resource, err error) *ResourceResult {
+	err = fmt.Errorf("%s: %w", resource, err)
+
+	r.logWithFields().Error(err)
+	r.addDrift(newResourceDiff(resource, SyncStatusOutOfSync, err.Error()))
+
+	result := newResourceResult(resource, ResourceOperationFailed, err.Error())
+	result.Err = err
+
+	return result
+}
+
+// setTrackingLabels merges tracking labels into labels of the object, labels from the sources are kept
+func setTrackingLabels(obj *unstructured.Unstructured, tracking map[string]string) {
+	objLabels := obj.GetLabels()
+
+	if objLabels == nil {
+		objLabels = make(map[string]string, len(tracking))
+	}
+
+	for key, value := range tracking {
+		objLabels[key] = value
+	}
+
+	obj.SetLabels(objLabels)
+}
+
+// checkOwner returns error if the live object is not created by the application, objects of other applications
+// and objects not managed by any application are adopted only when AllowAdoption is set
+func (r *RawProvider) checkOwner(live *unstructured.Unstructured) error {
+	owner, managed := live.GetLabels()["dummy.cd/app"]
+
+	if owner == *r.appName || r.ActionOptions.AllowAdoption {
+		return nil
+	}
+
+	if managed {
+		return fmt.Errorf("%w: %s, enable allowAdoption to take it over", util.ErrResourceOwnedByAnotherApp, owner)
+	}
+
+	return fmt.Errorf("%w, enable allowAdoption to take it over", util.ErrResourceNotManaged)
+}
+
+// upgradeManagedFields moves fields owned by client-side create/update of previous releases to the server-side apply manager,
+// so fields removed from git are removed from the object on the next apply
+func (r *RawProvider) upgradeManagedFields(resource *Resource, remoteResource *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(remoteResource, sets.New[string](FieldManager), FieldManager)
+
+	if err != nil || patch == nil {
+		return err
+	}
+
+	_, err = r.resourceInterface(resource).Patch(r.ctx, resource.obj.GetName(), types.JSONPatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+// resourceInterface returns dynamic client of the resolved resource
+func (r *RawProvider) resourceInterface(resource *Resource) dynamic.ResourceInterface {
+	return newResourceInterface(r.dynamicClient, resource)
+}
+
+// checkAllowed returns error if the application is not allowed to manage the namespace or cluster-scoped kind
+func (r *RawProvider) checkAllowed(groupKind schema.GroupKind, namespaced bool, namespace string) error {
+	if namespaced {
+		if namespace == *r.namespace || util.ContainsString(r.ActionOptions.AllowedNamespaces, "*") ||
+			util.ContainsString(r.ActionOptions.AllowedNamespaces, namespace) {
+			return nil
 		}
 
-	} else {
-		p.logWithFields().Debugf(
-			"revision already applied for %+v", r,
-		)
+		return fmt.Errorf("%w: namespace %s", util.ErrResourceNotAllowed, namespace)
 	}
+
+	if r.isClusterResourceAllowed(groupKind) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: cluster-scoped %s", util.ErrResourceNotAllowed, groupKind.String())
+}
+
+func (r *RawProvider) isClusterResourceAllowed(groupKind schema.GroupKind) bool {
+	for _, allowed := range r.ActionOptions.AllowedClusterResources {
+		if allowed == "*" || allowed == groupKind.Kind || allowed == groupKind.Group+"/"+groupKind.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getManagedNamespaces returns namespaces to search application resources in, metav1.NamespaceAll if any namespace allowed
+func (r *RawProvider) getManagedNamespaces() []string {
+	if util.ContainsString(r.ActionOptions.AllowedNamespaces, "*") {
+		return []string{metav1.NamespaceAll}
+	}
+
+	namespaces := []string{*r.namespace}
+
+	for _, namespace := range r.ActionOptions.AllowedNamespaces {
+		if !util.ContainsString(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	return namespaces
 }
 
 func (r *RawProvider) Uninstall() error {
 	for {
 		if r.mutex.TryLock() {
-			err := r.cleanResources(true)
+			if err := r.cleanResources(nil); err != nil {
+				return err
+			}
 
-			return err
+			return r.deleteSnapshot()
 		} else {
 			r.logWithFields().Debug("pending all resources removal")
 		}
@@ -368,42 +741,375 @@ func (r *RawProvider) Delivery() error {
 	_, revisionLabelExist := r.labels["dummy.cd/revision"]
 
 	if revisionLabelExist {
-		resourceFiles, err := r.getResourceFiles()
+		var err error
+
+		r.resources, err = r.render()
 
 		if err != nil {
 			r.logWithFields().Error(err)
 			return err
 		}
+	}
 
-		r.resources = NewUnstructuredResources(resourceFiles)
+	// the rolled back revision is not delivered again, so atomic applications do not flap between revisions,
+	// resources of the snapshot are kept in sync instead
+	if r.ActionOptions.Atomic {
+		rolledBack, err := r.isRolledBack(r.appRevision.String())
+
+		if err != nil {
+			r.logWithFields().Error(err)
+			return err
+		}
+
+		if rolledBack {
+			return r.syncSnapshot()
+		}
 	}
 
 	r.labels["dummy.cd/revision"] = r.appRevision.String()
 
-	var wg sync.WaitGroup
+	r.statusMutex.Lock()
+	r.drifts = nil
+	r.statusMutex.Unlock()
+
+	startedAt := time.Now()
+
+	// hooks run once per revision, delivery of the same revision only keeps resources in sync
+	hookRevision, err := r.getHookRevision()
 
-	for _, resource := range r.resources {
-		wg.Add(1)
-		go resource.Delivery(r, &wg)
+	if err != nil {
+		r.logWithFields().Error(err)
+		return err
 	}
 
-	wg.Wait()
+	runHooks := hookRevision != r.appRevision.String()
 
-	r.logWithFields().Debug("done apply resources")
+	resources, hooks := splitHooks(r.resources)
+
+	results, err := r.syncResources(resources, hooks, runHooks)
+
+	// nothing is written when validation fails, so SyncFail hooks are not run
+	if err != nil && runHooks && !errors.Is(err, util.ErrValidationFailed) {
+		failResults, failErr := r.runHooks(hooks, HookSyncFail)
+		results = append(results, failResults...)
+
+		if failErr != nil {
+			r.logWithFields().Error(failErr)
+		}
+	}
+
+	record := newSyncRecord(r.appRevision.String(), SyncRecordFailed, fmt.Sprint(err), startedAt)
+
+	if err != nil && r.ActionOptions.Atomic && !errors.Is(err, util.ErrValidationFailed) {
+		rollbackResults, revision, rollbackErr := r.rollback()
+		results = append(results, rollbackResults...)
+
+		if rollbackErr != nil {
+			r.logWithFields().Errorf("rollback: %s", rollbackErr)
+			record.Message = fmt.Sprintf("%s; rollback: %s", err, rollbackErr)
+		} else {
+			r.logWithFields().Warnf("rolled back to revision %s", revision)
+			record = newSyncRecord(r.appRevision.String(), SyncRecordRolledBack, fmt.Sprintf("rolled back to revision %s: %s", revision, err), startedAt)
+		}
+	}
+
+	r.statusMutex.Lock()
+	r.sync = NewApplicationSync(r.drifts)
+	r.sync.Results = results
+	r.statusMutex.Unlock()
+
+	if err != nil {
+		r.history.add(record)
+		r.logWithFields().Error(err)
+		return err
+	}
+
+	if runHooks {
+		r.history.add(newSyncRecord(r.appRevision.String(), SyncRecordSucceeded, "", startedAt))
+
+		if err := r.setHookRevision(r.appRevision.String()); err != nil {
+			r.logWithFields().Errorf("hook revision: %s", err)
+		}
+	}
+
+	if r.ActionOptions.Atomic {
+		if err := r.saveSnapshot(resources); err != nil {
+			r.logWithFields().Errorf("snapshot: %s", err)
+		}
+	}
 
 	if r.mutex.TryLock() {
-		go func() {
-			err := r.cleanResources(false)
+		go func(current []*Resource) {
+			err := r.cleanResources(current)
 
 			if err != nil {
 				r.logWithFields().Error(err)
 			}
 
 			r.logWithFields().Debug("cleanup task is done")
-		}()
+		}(r.resources)
 	} else {
 		r.logWithFields().Debug("skip running cleanup, task already in process")
 	}
 
 	return nil
 }
+
+// syncResources runs PreSync hooks, applies the resources, waits for them to become healthy if required and runs PostSync hooks
+func (r *RawProvider) syncResources(resources []*Resource, hooks []*Resource, runHooks bool) ([]*ResourceResult, error) {
+	var results []*ResourceResult
+
+	// hooks are resolved on every delivery to keep them in the inventory of the current resources
+	if err := resolveResources(r.mapper, hooks, *r.namespace); err != nil {
+		return nil, err
+	}
+
+	if r.ActionOptions.Validate {
+		validateResults, err := r.validateResources(append(append([]*Resource{}, resources...), hooks...))
+
+		if err != nil {
+			return validateResults, err
+		}
+
+		r.logWithFields().Debug("resources are validated")
+	}
+
+	if err := r.initInventory(resources); err != nil {
+		return nil, fmt.Errorf("inventory: %w", err)
+	}
+
+	if runHooks {
+		hookResults, err := r.runHooks(hooks, HookPreSync)
+		results = append(results, hookResults...)
+
+		if err != nil {
+			return results, err
+		}
+	}
+
+	applyResults, err := r.applyResources(resources)
+	results = append(results, applyResults...)
+
+	if err != nil {
+		return results, err
+	}
+
+	r.logWithFields().Debug("done apply resources")
+
+	if r.ActionOptions.WaitHealthy || r.ActionOptions.Atomic {
+		timeout := r.ActionOptions.HealthTimeout
+
+		if timeout == 0 {
+			timeout = *healthTimeout
+		}
+
+		if err := waitHealthy(r.ctx, r.dynamicClient, resources, timeout); err != nil {
+			return results, fmt.Errorf("wait healthy: %w", err)
+		}
+
+		r.logWithFields().Debug("resources are healthy")
+	}
+
+	if runHooks {
+		hookResults, err := r.runHooks(hooks, HookPostSync)
+		results = append(results, hookResults...)
+
+		if err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// applyResources applies the resources in sync wave and kind order, resources of a step are applied concurrently,
+// a wave waits for the previous one to become ready and Namespaces and CRDs are waited within a wave.
+// Failed resources are not waited and stop delivery of the next waves, errors of all of them are joined.
+// Applied resources are added to the inventory to be pruned once they are removed from the sources
+func (r *RawProvider) applyResources(resources []*Resource) ([]*ResourceResult, error) {
+	steps := newSyncSteps(resources, false)
+
+	var results []*ResourceResult
+	var errs []error
+	var waveResources []*Resource
+	var appliedResources []*Resource
+
+	for i, step := range steps {
+		var wg sync.WaitGroup
+
+		stepResults := make([]*ResourceResult, len(step.resources))
+
+		for j, resource := range step.resources {
+			wg.Add(1)
+			go func(j int, resource *Resource) {
+				defer wg.Done()
+				stepResults[j] = resource.Delivery(r)
+			}(j, resource)
+		}
+
+		wg.Wait()
+
+		var applied []*Resource
+
+		for j, result := range stepResults {
+			if result.Err != nil {
+				errs = append(errs, result.Err)
+			} else {
+				applied = append(applied, step.resources[j])
+			}
+		}
+
+		results = append(results, stepResults...)
+		waveResources = append(waveResources, applied...)
+		appliedResources = append(appliedResources, applied...)
+
+		if i+1 < len(steps) && steps[i+1].wave != step.wave {
+			if len(errs) > 0 {
+				errs = append(errs, fmt.Errorf("sync wave %d failed, next waves are not applied", step.wave))
+				break
+			}
+
+			if err := waitReady(r.ctx, r.dynamicClient, waveResources); err != nil {
+				errs = append(errs, fmt.Errorf("sync wave %d: %w", step.wave, err))
+				break
+			}
+
+			waveResources = nil
+		} else if i+1 < len(steps) && step.requiresReady() {
+			if err := waitReady(r.ctx, r.dynamicClient, applied); err != nil {
+				errs = append(errs, fmt.Errorf("sync wave %d: %w", step.wave, err))
+				break
+			}
+		}
+
+		if step.hasKind("CustomResourceDefinition") {
+			r.mapper.Reset()
+		}
+	}
+
+	if err := r.updateInventory(appliedResources, nil); err != nil {
+		errs = append(errs, fmt.Errorf("inventory: %w", err))
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// renderResolved returns resolved resources of the current revision with tracking labels as they are applied
+func (r *RawProvider) renderResolved() ([]*Resource, error) {
+	resources, err := r.render()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveResources(r.mapper, resources, *r.namespace); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		setTrackingLabels(resource.obj, map[string]string{
+			"dummy.cd/app":      *r.appName,
+			"dummy.cd/revision": r.appRevision.String(),
+		})
+	}
+
+	return resources, nil
+}
+
+func (r *RawProvider) addDrift(drift *ResourceDiff) {
+	r.statusMutex.Lock()
+	defer r.statusMutex.Unlock()
+
+	r.drifts = append(r.drifts, drift)
+}
+
+// Status returns sync status of the last delivery with the objects pending prune, which keep the application out of sync
+func (r *RawProvider) Status() *ApplicationSync {
+	r.statusMutex.Lock()
+	defer r.statusMutex.Unlock()
+
+	if r.sync == nil {
+		return nil
+	}
+
+	status := *r.sync
+	status.Prunes = r.getPendingPrunes()
+	status.History = r.history.list()
+
+	if len(status.Prunes) > 0 {
+		status.Status = SyncStatusOutOfSync
+	}
+
+	return &status
+}
+
+func (r *RawProvider) Render() ([]*unstructured.Unstructured, error) {
+	resources, err := r.renderResolved()
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return nil, err
+	}
+
+	return getResourcesObjects(resources), nil
+}
+
+func (r *RawProvider) Diff() ([]*ResourceDiff, error) {
+	resources, err := r.renderResolved()
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return nil, err
+	}
+
+	var diffs []*ResourceDiff
+
+	for _, resource := range resources {
+		if IsHook(resource.obj) {
+			continue
+		}
+
+		live, err := getLiveObject(r.ctx, r.dynamicClient, resource)
+
+		if err != nil {
+			r.logWithFields().Error(err)
+			return nil, err
+		}
+
+		if live == nil {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusMissing, "object not found"))
+		} else if err := r.checkOwner(live); err != nil {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusOutOfSync, err.Error()))
+		} else if liveRevision := live.GetLabels()["dummy.cd/revision"]; liveRevision != r.appRevision.String() {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusOutOfSync, fmt.Sprintf("revision %s applied", liveRevision)))
+		} else if fields := DiffResource(resource.obj, live, r.ignoreRules); len(fields) > 0 {
+			diff := newResourceDiff(resource, SyncStatusOutOfSync, "drifted from the git state")
+			diff.Fields = fields
+			diffs = append(diffs, diff)
+		} else {
+			diffs = append(diffs, newResourceDiff(resource, SyncStatusSynced, ""))
+		}
+	}
+
+	return diffs, nil
+}
+
+func (r *RawProvider) Health() (*ApplicationHealth, error) {
+	resources, err := r.renderResolved()
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return nil, err
+	}
+
+	resources, _ = splitHooks(resources)
+
+	health, err := getResourcesHealth(r.ctx, r.dynamicClient, resources)
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return nil, err
+	}
+
+	return NewApplicationHealth(health), nil
+}
@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestObject decodes single yaml object of the test
+func newTestObject(t *testing.T, manifest string) *unstructured.Unstructured {
+	t.Helper()
+
+	objs, err := NewUnstructuredObjects([]byte(manifest))
+
+	if err != nil || len(objs) != 1 {
+		t.Fatalf("invalid test manifest: %v: %s", err, manifest)
+	}
+
+	return objs[0]
+}
+
+// newTestRawProvider returns provider of the app application in the default namespace
+func newTestRawProvider(options RawActionOptions) *RawProvider {
+	appName := "app"
+	namespace := "default"
+
+	return &RawProvider{
+		ActionOptions: &options,
+		appName:       &appName,
+		namespace:     &namespace,
+		appRevision:   &plumbing.ZeroHash,
+		statusMutex:   new(sync.Mutex),
+		ctx:           context.Background(),
+	}
+}
+
+// newTestDynamicClient returns dynamic client of the test server handling api requests
+func newTestDynamicClient(t *testing.T, handler http.HandlerFunc) *dynamic.DynamicClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, req)
+	}))
+
+	t.Cleanup(server.Close)
+
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dynamicClient
+}
+
+// writeTestStatus writes failure status of the api request
+func writeTestStatus(w http.ResponseWriter, code int, reason string) {
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `{"apiVersion": "v1", "kind": "Status", "status": "Failure", "reason": %q, "message": %q, "code": %d}`, reason, reason, code)
+}
+
+func TestResourceResolve(t *testing.T) {
+	mapper, _ := newTestResourceMapper(testAPIResources)
+
+	tests := []struct {
+		name          string
+		manifest      string
+		wantNamespace string
+		namespaced    bool
+	}{
+		{
+			name:          "default namespace",
+			manifest:      "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n",
+			wantNamespace: "default",
+			namespaced:    true,
+		},
+		{
+			name:          "explicit namespace",
+			manifest:      "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, namespace: other}\n",
+			wantNamespace: "other",
+			namespaced:    true,
+		},
+		{
+			name:     "cluster-scoped with namespace",
+			manifest: "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata: {name: a, namespace: other}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := &Resource{obj: newTestObject(t, tt.manifest)}
+
+			if err := resource.resolve(mapper, "default"); err != nil {
+				t.Fatal(err)
+			}
+
+			if resource.namespaced != tt.namespaced {
+				t.Errorf("resolve() namespaced = %v, want %v", resource.namespaced, tt.namespaced)
+			}
+
+			if resource.namespace != tt.wantNamespace || resource.obj.GetNamespace() != tt.wantNamespace {
+				t.Errorf("resolve() namespace = %q, object namespace %q, want %q", resource.namespace, resource.obj.GetNamespace(), tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestCheckAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    RawActionOptions
+		groupKind  schema.GroupKind
+		namespaced bool
+		namespace  string
+		wantErr    error
+	}{
+		{
+			name:       "application namespace",
+			groupKind:  schema.GroupKind{Kind: "ConfigMap"},
+			namespaced: true,
+			namespace:  "default",
+		},
+		{
+			name:       "other namespace",
+			groupKind:  schema.GroupKind{Kind: "ConfigMap"},
+			namespaced: true,
+			namespace:  "other",
+			wantErr:    util.ErrResourceNotAllowed,
+		},
+		{
+			name:       "allowed namespace",
+			options:    RawActionOptions{AllowedNamespaces: []string{"other"}},
+			groupKind:  schema.GroupKind{Kind: "ConfigMap"},
+			namespaced: true,
+			namespace:  "other",
+		},
+		{
+			name:       "any namespace",
+			options:    RawActionOptions{AllowedNamespaces: []string{"*"}},
+			groupKind:  schema.GroupKind{Kind: "ConfigMap"},
+			namespaced: true,
+			namespace:  "other",
+		},
+		{
+			name:      "cluster-scoped",
+			groupKind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+			wantErr:   util.ErrResourceNotAllowed,
+		},
+		{
+			name:      "allowed kind",
+			options:   RawActionOptions{AllowedClusterResources: []string{"ClusterRole"}},
+			groupKind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+		},
+		{
+			name:      "allowed group kind",
+			options:   RawActionOptions{AllowedClusterResources: []string{"rbac.authorization.k8s.io/ClusterRole"}},
+			groupKind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+		},
+		{
+			name:      "other group kind",
+			options:   RawActionOptions{AllowedClusterResources: []string{"example.com/ClusterRole"}},
+			groupKind: schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+			wantErr:   util.ErrResourceNotAllowed,
+		},
+		{
+			name:      "any kind",
+			options:   RawActionOptions{AllowedClusterResources: []string{"*"}},
+			groupKind: schema.GroupKind{Kind: "Namespace"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRawProvider(tt.options)
+
+			if err := r.checkAllowed(tt.groupKind, tt.namespaced, tt.namespace); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkAllowed() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpgradeManagedFields(t *testing.T) {
+	const configMap = "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: default\n  managedFields:\n"
+
+	tests := []struct {
+		name      string
+		live      string
+		wantPatch bool
+	}{
+		{
+			name:      "client-side update of the manager",
+			live:      configMap + "  - {manager: dummycd, operation: Update, apiVersion: v1, fieldsType: FieldsV1, fieldsV1: {'f:data': {'f:a': {}}}}\n",
+			wantPatch: true,
+		},
+		{
+			name: "server-side apply",
+			live: configMap + "  - {manager: dummycd, operation: Apply, apiVersion: v1, fieldsType: FieldsV1, fieldsV1: {'f:data': {'f:a': {}}}}\n",
+		},
+		{
+			name: "update of other manager",
+			live: configMap + "  - {manager: kubectl, operation: Update, apiVersion: v1, fieldsType: FieldsV1, fieldsV1: {'f:data': {'f:a': {}}}}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patches []string
+
+			r := newTestRawProvider(RawActionOptions{})
+			r.dynamicClient = newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+				patches = append(patches, req.Method+" "+req.URL.Path+" "+req.Header.Get("Content-Type"))
+				_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}`))
+			})
+
+			resource := &Resource{
+				obj:        newTestObject(t, tt.live),
+				gvr:        schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+				namespaced: true,
+				namespace:  "default",
+			}
+
+			if err := r.upgradeManagedFields(resource, resource.obj); err != nil {
+				t.Fatal(err)
+			}
+
+			var want []string
+
+			if tt.wantPatch {
+				want = []string{"PATCH /api/v1/namespaces/default/configmaps/a application/json-patch+json"}
+			}
+
+			if fmt.Sprint(patches) != fmt.Sprint(want) {
+				t.Errorf("upgradeManagedFields() requests = %v, want %v", patches, want)
+			}
+		})
+	}
+}
+
+func TestResourceDelivery(t *testing.T) {
+	const (
+		configMap = "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\ndata: {key: a}\n"
+		livePath  = "/api/v1/namespaces/default/configmaps/a"
+	)
+
+	tests := []struct {
+		name        string
+		options     RawActionOptions
+		manifest    string
+		live        string
+		applyStatus int
+		want        ResourceOperation
+		wantMessage string
+		wantErr     error
+		wantApply   string
+		wantDrift   bool
+	}{
+		{
+			name:      "created",
+			manifest:  configMap,
+			want:      ResourceOperationCreated,
+			wantApply: "PATCH " + livePath + " force=false",
+		},
+		{
+			name:     "unchanged",
+			manifest: configMap,
+			live: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "namespace": "default", ` +
+				`"labels": {"dummy.cd/app": "app", "dummy.cd/revision": "r2"}}, "data": {"key": "a"}}`,
+			want: ResourceOperationUnchanged,
+		},
+		{
+			name:     "drift without self-heal",
+			manifest: configMap,
+			live: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "namespace": "default", ` +
+				`"labels": {"dummy.cd/app": "app", "dummy.cd/revision": "r2"}}, "data": {"key": "b"}}`,
+			want:        ResourceOperationUnchanged,
+			wantMessage: "drift detected, self-heal is disabled",
+			wantDrift:   true,
+		},
+		{
+			name:     "self-heal",
+			options:  RawActionOptions{SelfHeal: true},
+			manifest: configMap,
+			live: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a", "namespace": "default", ` +
+				`"labels": {"dummy.cd/app": "app", "dummy.cd/revision": "r2"}}, "data": {"key": "b"}}`,
+			want:        ResourceOperationUpdated,
+			wantMessage: "drift self-healed",
+			wantApply:   "PATCH " + livePath + " force=true",
+		},
+		{
+			name:        "namespace not allowed",
+			manifest:    "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, namespace: other}\n",
+			want:        ResourceOperationFailed,
+			wantErr:     util.ErrResourceNotAllowed,
+			wantDrift:   true,
+			wantMessage: "namespace other",
+		},
+		{
+			name:        "field conflict",
+			manifest:    configMap,
+			applyStatus: http.StatusConflict,
+			want:        ResourceOperationFailed,
+			wantMessage: "enable forceConflicts",
+			wantApply:   "PATCH " + livePath + " force=false",
+			wantDrift:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var applies []string
+
+			mapper, _ := newTestResourceMapper(testAPIResources)
+
+			r := newTestRawProvider(tt.options)
+			r.mapper = mapper
+			r.labels = map[string]string{"dummy.cd/app": "app", "dummy.cd/revision": "r2"}
+			r.dynamicClient = newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+				switch {
+				case req.Method == http.MethodGet && len(tt.live) > 0:
+					_, _ = w.Write([]byte(tt.live))
+				case req.Method == http.MethodGet:
+					writeTestStatus(w, http.StatusNotFound, "NotFound")
+				case req.Method == http.MethodPatch:
+					applies = append(applies, req.Method+" "+req.URL.Path+" force="+req.URL.Query().Get("force"))
+
+					if tt.applyStatus == http.StatusConflict {
+						writeTestStatus(w, http.StatusConflict, "Conflict")
+						return
+					}
+
+					_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}`))
+				}
+			})
+
+			result := (&Resource{obj: newTestObject(t, tt.manifest)}).Delivery(r)
+
+			if result.Operation != tt.want || !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("Delivery() = %s %q, want %s %q", result.Operation, result.Message, tt.want, tt.wantMessage)
+			}
+
+			if (result.Err != nil) != (tt.want == ResourceOperationFailed) || tt.wantErr != nil && !errors.Is(result.Err, tt.wantErr) {
+				t.Errorf("Delivery() error = %v, want %v", result.Err, tt.wantErr)
+			}
+
+			var wantApplies []string
+
+			if len(tt.wantApply) > 0 {
+				wantApplies = append(wantApplies, tt.wantApply)
+			}
+
+			if fmt.Sprint(applies) != fmt.Sprint(wantApplies) {
+				t.Errorf("Delivery() applies = %v, want %v", applies, wantApplies)
+			}
+
+			if (len(r.drifts) > 0) != tt.wantDrift {
+				t.Errorf("Delivery() drifts = %d, want drift %v", len(r.drifts), tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestSetTrackingLabels(t *testing.T) {
+	obj := newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {team: web, dummy.cd/app: other}}\n")
+
+	setTrackingLabels(obj, map[string]string{"dummy.cd/app": "app", "dummy.cd/revision": "r2"})
+
+	want := map[string]string{"team": "web", "dummy.cd/app": "app", "dummy.cd/revision": "r2"}
+
+	if got := obj.GetLabels(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("setTrackingLabels() labels = %v, want %v", got, want)
+	}
+
+	unlabelled := newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n")
+
+	setTrackingLabels(unlabelled, map[string]string{"dummy.cd/app": "app"})
+
+	if got := unlabelled.GetLabels()["dummy.cd/app"]; got != "app" {
+		t.Errorf("setTrackingLabels() of unlabelled object app = %q, want app", got)
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowAdoption bool
+		labels        string
+		wantErr       error
+	}{
+		{name: "own object", labels: "{dummy.cd/app: app}"},
+		{name: "object of other application", labels: "{dummy.cd/app: other}", wantErr: util.ErrResourceOwnedByAnotherApp},
+		{name: "unmanaged object", labels: "{team: web}", wantErr: util.ErrResourceNotManaged},
+		{name: "adoption of other application object", allowAdoption: true, labels: "{dummy.cd/app: other}"},
+		{name: "adoption of unmanaged object", allowAdoption: true, labels: "{}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRawProvider(RawActionOptions{AllowAdoption: tt.allowAdoption})
+			live := newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: "+tt.labels+"}\n")
+
+			if err := r.checkOwner(live); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkOwner() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/client-go/rest"
+	"sync"
+)
+
+const (
+	ProviderPlugin    = "plugin"
+	ProviderHelm      = "helm"
+	ProviderKustomize = "kustomize"
+	ProviderJsonnet   = "jsonnet"
+	ProviderRaw       = "raw"
+)
+
+var (
+	providerRegistry      []*ProviderRegistration
+	providerRegistryMutex = new(sync.Mutex)
+)
+
+// ProviderOptions holds the application data passed to provider detection and construction
+type ProviderOptions struct {
+	Ctx context.Context
+	// SourcePath is the first sparse path of the checked-out repository, SourcePaths are all of them
+	SourcePath     *string
+	SourcePaths    *[]string
+	RepositoryPath *string
+	FileFilter     *util.FileFilter
+	AppName        *string
+	Namespace      *string
+	KubeConfig     *rest.Config
+	AppRevision    *plumbing.Hash
+	Helm           *HelmProvider
+	Raw            *RawProvider
+	Jsonnet        *JsonnetProvider
+	Plugin         *PluginProvider
+	// IgnoreDifferences of the application are used by diff, drift detection and self-heal besides the global ones
+	IgnoreDifferences []IgnoreDifference
+}
+
+// ProviderRegistration declares detection rule and constructor of the delivery provider
+type ProviderRegistration struct {
+	Name   string
+	Detect func(options *ProviderOptions) bool
+	New    func(options *ProviderOptions) (DeliveryProvider, error)
+}
+
+func init() {
+	RegisterProvider(&ProviderRegistration{
+		Name: ProviderPlugin,
+		Detect: func(options *ProviderOptions) bool {
+			return len(options.Plugin.Name) > 0
+		},
+		New: func(options *ProviderOptions) (DeliveryProvider, error) {
+			return NewPluginProvider(options.Ctx, options.AppName, options.SourcePath, options.Namespace, options.Plugin, options.Raw, options.KubeConfig, options.AppRevision)
+		},
+	})
+
+	RegisterProvider(&ProviderRegistration{
+		Name: ProviderHelm,
+		Detect: func(options *ProviderOptions) bool {
+			isChart, _ := chartutil.IsChartDir(*options.SourcePath)
+			return isChart
+		},
+		New: func(options *ProviderOptions) (DeliveryProvider, error) {
+			return NewHelmProvider(options.AppName, options.SourcePath, options.Namespace, options.Helm, options.KubeConfig, options.AppRevision)
+		},
+	})
+
+	RegisterProvider(&ProviderRegistration{
+		Name: ProviderKustomize,
+		Detect: func(options *ProviderOptions) bool {
+			return IsKustomizationDir(*options.SourcePath)
+		},
+		New: func(options *ProviderOptions) (DeliveryProvider, error) {
+			return NewKustomizeProvider(options.Ctx, options.AppName, options.SourcePath, options.Namespace, options.Raw, options.KubeConfig, options.AppRevision)
+		},
+	})
+
+	RegisterProvider(&ProviderRegistration{
+		Name: ProviderJsonnet,
+		Detect: func(options *ProviderOptions) bool {
+			return IsJsonnetDir(*options.SourcePath, options.Jsonnet.Entrypoint)
+		},
+		New: func(options *ProviderOptions) (DeliveryProvider, error) {
+			return NewJsonnetProvider(options.Ctx, options.AppName, options.SourcePath, options.RepositoryPath, options.Namespace, options.Jsonnet, options.Raw, options.KubeConfig, options.AppRevision)
+		},
+	})
+
+	RegisterProvider(&ProviderRegistration{
+		Name: ProviderRaw,
+		Detect: func(options *ProviderOptions) bool {
+			return true
+		},
+		New: func(options *ProviderOptions) (DeliveryProvider, error) {
+			return NewRawProvider(options.Ctx, options.AppName, options.SourcePaths, options.FileFilter, options.Namespace, options.Raw, options.KubeConfig, options.AppRevision)
+		},
+	})
+}
+
+// RegisterProvider adds the provider to the registry, detection runs in registration order
+// and the provider registered later with the same name replaces the previous one
+func RegisterProvider(registration *ProviderRegistration) {
+	providerRegistryMutex.Lock()
+	defer providerRegistryMutex.Unlock()
+
+	for i, registered := range providerRegistry {
+		if registered.Name == registration.Name {
+			providerRegistry[i] = registration
+			return
+		}
+	}
+
+	providerRegistry = append(providerRegistry, registration)
+}
+
+// GetProviderRegistration returns the provider by name or the first provider detected if the name is empty
+func GetProviderRegistration(name string, options *ProviderOptions) (*ProviderRegistration, error) {
+	providerRegistryMutex.Lock()
+	defer providerRegistryMutex.Unlock()
+
+	for _, registration := range providerRegistry {
+		if len(name) > 0 && registration.Name == name {
+			return registration, nil
+		}
+
+		if len(name) == 0 && registration.Detect(options) {
+			return registration, nil
+		}
+	}
+
+	if len(name) > 0 {
+		return nil, fmt.Errorf("%w: %s", util.ErrProviderNotFound, name)
+	}
+
+	return nil, util.ErrProviderNotFound
+}
+
+// NewDeliveryProvider returns the provider by name or detected from application sources and its name
+func NewDeliveryProvider(name string, options *ProviderOptions) (DeliveryProvider, string, error) {
+	registration, err := GetProviderRegistration(name, options)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	ignoreRules, err := NewIgnoreRules(options.IgnoreDifferences)
+
+	if err != nil {
+		return nil, registration.Name, err
+	}
+
+	if options.Raw != nil {
+		options.Raw.ignoreRules = ignoreRules
+	}
+
+	if options.Helm != nil {
+		options.Helm.ignoreRules = ignoreRules
+	}
+
+	deliveryProvider, err := registration.New(options)
+
+	if err != nil {
+		return nil, registration.Name, err
+	}
+
+	return deliveryProvider, registration.Name, nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"errors"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetProviderRegistration(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{
+		"chart/Chart.yaml":               "apiVersion: v2\nname: a\nversion: 0.1.0\n",
+		"kustomize/kustomization.yaml":   "resources: []\n",
+		"jsonnet/main.jsonnet":           "{}\n",
+		"jsonnet-entrypoint/app.jsonnet": "{}\n",
+		"raw/deployment.yaml":            "",
+	})
+
+	tests := []struct {
+		name       string
+		provider   string
+		dir        string
+		plugin     string
+		entrypoint string
+		want       string
+		wantErr    error
+	}{
+		{name: "helm chart", dir: "chart", want: ProviderHelm},
+		{name: "kustomization", dir: "kustomize", want: ProviderKustomize},
+		{name: "jsonnet", dir: "jsonnet", want: ProviderJsonnet},
+		{name: "jsonnet entrypoint", dir: "jsonnet-entrypoint", entrypoint: "app.jsonnet", want: ProviderJsonnet},
+		{name: "raw manifests", dir: "raw", want: ProviderRaw},
+		{name: "plugin takes precedence", dir: "chart", plugin: "generate", want: ProviderPlugin},
+		{name: "explicit provider", provider: ProviderRaw, dir: "kustomize", want: ProviderRaw},
+		{name: "unknown provider", provider: "cue", dir: "raw", wantErr: util.ErrProviderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourcePath := filepath.Join(root, tt.dir)
+
+			registration, err := GetProviderRegistration(tt.provider, &ProviderOptions{
+				SourcePath: &sourcePath,
+				Plugin:     &PluginProvider{Name: tt.plugin},
+				Jsonnet:    &JsonnetProvider{Entrypoint: tt.entrypoint},
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetProviderRegistration() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && registration.Name != tt.want {
+				t.Errorf("GetProviderRegistration() = %s, want %s", registration.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterProvider(t *testing.T) {
+	providerRegistryMutex.Lock()
+	registry := append([]*ProviderRegistration{}, providerRegistry...)
+	providerRegistryMutex.Unlock()
+
+	defer func() {
+		providerRegistryMutex.Lock()
+		providerRegistry = registry
+		providerRegistryMutex.Unlock()
+	}()
+
+	root := t.TempDir()
+
+	writeTestFiles(t, root, map[string]string{"kustomization.yaml": "resources: []\n"})
+
+	options := &ProviderOptions{SourcePath: &root, Plugin: &PluginProvider{}, Jsonnet: &JsonnetProvider{}}
+
+	// the replaced provider keeps its position, the new one is detected after the raw provider, so only by name
+	RegisterProvider(&ProviderRegistration{Name: ProviderKustomize, Detect: func(options *ProviderOptions) bool { return false }})
+	RegisterProvider(&ProviderRegistration{Name: "cue", Detect: func(options *ProviderOptions) bool { return true }})
+
+	if registration, err := GetProviderRegistration("", options); err != nil || registration.Name != ProviderRaw {
+		t.Errorf("GetProviderRegistration() = %v, %v, want %s", registration, err, ProviderRaw)
+	}
+
+	if registration, err := GetProviderRegistration("cue", options); err != nil || registration.Name != "cue" {
+		t.Errorf("GetProviderRegistration(cue) = %v, %v", registration, err)
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strconv"
+	"strings"
+)
+
+// ReplaceAnnotation set to "true" or "false" overrides Replace option of the application for the object
+const ReplaceAnnotation = "dummy.cd/replace"
+
+// isImmutableFieldError reports whether the object is rejected because immutable fields are changed
+func isImmutableFieldError(err error) bool {
+	if !k8sErrors.IsInvalid(err) {
+		return false
+	}
+
+	message := err.Error()
+
+	return strings.Contains(message, "immutable") || strings.Contains(message, "may not change once set")
+}
+
+// isReplaceAllowed reports whether the object can be deleted and created again when its immutable fields are changed
+func (r *RawProvider) isReplaceAllowed(resource *Resource) bool {
+	value, exist := resource.obj.GetAnnotations()[ReplaceAnnotation]
+
+	if !exist {
+		return r.ActionOptions.Replace
+	}
+
+	replace, err := strconv.ParseBool(value)
+
+	if err != nil {
+		r.logWithFields().Warnf("invalid %s annotation %q of %+v, application option is used", ReplaceAnnotation, value, resource)
+		return r.ActionOptions.Replace
+	}
+
+	return replace
+}
+
+// replaceResource deletes the object and applies it again, ReplaceWait deletes dependents in foreground
+// and waits for the object to be removed, otherwise dependents are removed in background
+func (r *RawProvider) replaceResource(resource *Resource, obj *unstructured.Unstructured) error {
+	propagationPolicy := metav1.DeletePropagationBackground
+
+	if r.ActionOptions.ReplaceWait {
+		propagationPolicy = metav1.DeletePropagationForeground
+	}
+
+	err := r.resourceInterface(resource).Delete(r.ctx, resource.obj.GetName(), metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+
+	if err != nil && !k8sErrors.IsNotFound(err) {
+		return err
+	}
+
+	if r.ActionOptions.ReplaceWait {
+		if err := waitDeleted(r.ctx, r.dynamicClient, []*Resource{resource}); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.resourceInterface(resource).Apply(r.ctx, resource.obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+	})
+
+	return err
+}
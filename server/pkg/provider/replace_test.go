@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"testing"
+)
+
+func TestIsImmutableFieldError(t *testing.T) {
+	groupKind := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "immutable selector",
+			err: k8sErrors.NewInvalid(groupKind, "a", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+			}),
+			want: true,
+		},
+		{
+			name: "value may not change",
+			err: k8sErrors.NewInvalid(schema.GroupKind{Kind: "Service"}, "a", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "clusterIP"), "10.0.0.1", "may not change once set"),
+			}),
+			want: true,
+		},
+		{
+			name: "other invalid field",
+			err: k8sErrors.NewInvalid(groupKind, "a", field.ErrorList{
+				field.Required(field.NewPath("spec", "template"), ""),
+			}),
+		},
+		{
+			name: "not invalid",
+			err:  errors.New("field is immutable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isImmutableFieldError(tt.err); got != tt.want {
+				t.Errorf("isImmutableFieldError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsReplaceAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		replace    bool
+		annotation string
+		want       bool
+	}{
+		{name: "option disabled", want: false},
+		{name: "option enabled", replace: true, want: true},
+		{name: "annotation enables", annotation: "true", want: true},
+		{name: "annotation disables", replace: true, annotation: "false", want: false},
+		{name: "invalid annotation", replace: true, annotation: "yes please", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n"
+
+			if len(tt.annotation) > 0 {
+				manifest = "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, annotations: {" + ReplaceAnnotation + ": '" + tt.annotation + "'}}\n"
+			}
+
+			r := newTestRawProvider(RawActionOptions{Replace: tt.replace})
+
+			if got := r.isReplaceAllowed(&Resource{obj: newTestObject(t, manifest)}); got != tt.want {
+				t.Errorf("isReplaceAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
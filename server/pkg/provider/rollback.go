@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	snapshotPrefix      = "dummycd-snapshot-"
+	snapshotDataKey     = "manifests.yaml.gz"
+	snapshotRevisionKey = "dummy.cd/revision"
+	// snapshotRolledBackKey is the revision rolled back to the snapshot, it is cleared when a new snapshot is saved
+	snapshotRolledBackKey = "dummy.cd/rolled-back-revision"
+)
+
+// isRolledBack reports whether the revision was rolled back to the snapshot, so it survives restarts of the server
+func (r *RawProvider) isRolledBack(revision string) (bool, error) {
+	configMap, err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Get(r.ctx, r.snapshotName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return configMap.GetAnnotations()[snapshotRolledBackKey] == revision, nil
+}
+
+// setRolledBack records the revision rolled back to the snapshot
+func (r *RawProvider) setRolledBack(revision string) error {
+	configMaps := r.clientSet.CoreV1().ConfigMaps(*r.namespace)
+
+	configMap, err := configMaps.Get(r.ctx, r.snapshotName(), metav1.GetOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	annotations := configMap.GetAnnotations()
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	annotations[snapshotRolledBackKey] = revision
+	configMap.SetAnnotations(annotations)
+
+	_, err = configMaps.Update(r.ctx, configMap, metav1.UpdateOptions{})
+
+	return err
+}
+
+// snapshotName returns name of the ConfigMap holding manifests of the last known-good revision in the application namespace
+func (r *RawProvider) snapshotName() string {
+	return snapshotPrefix + *r.appName
+}
+
+// saveSnapshot stores gzipped manifests of the resources delivered and healthy at the current revision,
+// the rolled back revision of the previous snapshot is cleared
+func (r *RawProvider) saveSnapshot(resources []*Resource) error {
+	var manifests bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&manifests)
+
+	for _, resource := range resources {
+		data, err := yaml.Marshal(resource.obj.Object)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := gzipWriter.Write(append([]byte("---\n"), data...)); err != nil {
+			return err
+		}
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        r.snapshotName(),
+			Namespace:   *r.namespace,
+			Labels:      map[string]string{"dummy.cd/snapshot": *r.appName},
+			Annotations: map[string]string{snapshotRevisionKey: r.appRevision.String()},
+		},
+		BinaryData: map[string][]byte{snapshotDataKey: manifests.Bytes()},
+	}
+
+	configMaps := r.clientSet.CoreV1().ConfigMaps(*r.namespace)
+
+	_, err := configMaps.Update(r.ctx, configMap, metav1.UpdateOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		_, err = configMaps.Create(r.ctx, configMap, metav1.CreateOptions{})
+	}
+
+	return err
+}
+
+// getSnapshot returns revision and resources of the last known-good revision, empty revision if there is no snapshot
+func (r *RawProvider) getSnapshot() (string, []*Resource, error) {
+	configMap, err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Get(r.ctx, r.snapshotName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return "", nil, nil
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(configMap.BinaryData[snapshotDataKey]))
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	manifests, err := io.ReadAll(gzipReader)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	revision := configMap.GetAnnotations()[snapshotRevisionKey]
+
+	resources, err := NewManifestResources(manifests, fmt.Sprintf("snapshot %s", revision))
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return revision, resources, nil
+}
+
+// deleteSnapshot removes the snapshot on uninstall
+func (r *RawProvider) deleteSnapshot() error {
+	err := r.clientSet.CoreV1().ConfigMaps(*r.namespace).Delete(r.ctx, r.snapshotName(), metav1.DeleteOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// rollback applies resources of the last known-good revision and prunes objects created by the failed revision,
+// objects pruned since that revision are created again by the apply
+func (r *RawProvider) rollback() ([]*ResourceResult, string, error) {
+	revision, resources, err := r.getSnapshot()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(revision) == 0 || revision == r.appRevision.String() {
+		return nil, "", util.ErrNoKnownGoodRevision
+	}
+
+	r.logWithFields().Warnf("rolling back to revision %s", revision)
+
+	// objects of the snapshot keep tracking labels of the revision they were delivered with
+	r.labels["dummy.cd/revision"] = revision
+	defer func() { r.labels["dummy.cd/revision"] = r.appRevision.String() }()
+
+	results, err := r.applyResources(resources)
+
+	if err != nil {
+		return results, revision, err
+	}
+
+	if err := r.setRolledBack(r.appRevision.String()); err != nil {
+		r.logWithFields().Errorf("snapshot: %s", err)
+	}
+
+	if r.mutex.TryLock() {
+		go func(current []*Resource) {
+			if err := r.cleanResources(current); err != nil {
+				r.logWithFields().Error(err)
+			}
+		}(resources)
+	} else {
+		r.logWithFields().Debug("skip running cleanup after rollback, task already in process")
+	}
+
+	return results, revision, nil
+}
+
+// syncSnapshot keeps resources of the snapshot in sync while the current revision is rolled back,
+// hooks and resources of the rolled back revision are skipped and objects are pruned against the snapshot
+func (r *RawProvider) syncSnapshot() error {
+	revision, resources, err := r.getSnapshot()
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return err
+	}
+
+	r.logWithFields().Warnf("%s, syncing revision %s", util.ErrRevisionRolledBack, revision)
+
+	// objects of the snapshot keep tracking labels of the revision they were delivered with
+	r.labels["dummy.cd/revision"] = revision
+	defer func() { r.labels["dummy.cd/revision"] = r.appRevision.String() }()
+
+	r.statusMutex.Lock()
+	r.drifts = nil
+	r.statusMutex.Unlock()
+
+	results, err := r.applyResources(resources)
+
+	r.statusMutex.Lock()
+	r.sync = NewApplicationSync(r.drifts)
+	r.sync.Results = results
+	r.statusMutex.Unlock()
+
+	if err != nil {
+		r.logWithFields().Error(err)
+		return err
+	}
+
+	if r.mutex.TryLock() {
+		go func(current []*Resource) {
+			if err := r.cleanResources(current); err != nil {
+				r.logWithFields().Error(err)
+			}
+		}(resources)
+	} else {
+		r.logWithFields().Debug("skip running cleanup of snapshot, task already in process")
+	}
+
+	return util.ErrRevisionRolledBack
+}
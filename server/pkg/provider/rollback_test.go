@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestSnapshotRolledBack(t *testing.T) {
+	r := newTestRawProvider(RawActionOptions{})
+	r.clientSet = newTestConfigMapClientSet(t, map[string]*corev1.ConfigMap{})
+
+	resources := []*Resource{{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n")}}
+
+	isRolledBack := func(revision string, want bool) {
+		t.Helper()
+
+		got, err := r.isRolledBack(revision)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Errorf("isRolledBack(%s) = %v, want %v", revision, got, want)
+		}
+	}
+
+	isRolledBack("r1", false)
+
+	if err := r.saveSnapshot(resources); err != nil {
+		t.Fatal(err)
+	}
+
+	revision, snapshot, err := r.getSnapshot()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if revision != r.appRevision.String() || len(snapshot) != 1 || snapshot[0].obj.GetName() != "a" {
+		t.Errorf("getSnapshot() = %s, %d resources", revision, len(snapshot))
+	}
+
+	if err := r.setRolledBack("r1"); err != nil {
+		t.Fatal(err)
+	}
+
+	isRolledBack("r1", true)
+	isRolledBack("r2", false)
+
+	// the new snapshot clears the rolled back revision
+	if err := r.saveSnapshot(resources); err != nil {
+		t.Fatal(err)
+	}
+
+	isRolledBack("r1", false)
+}
+
+func TestDeliveryRolledBack(t *testing.T) {
+	const (
+		webPath          = "/api/v1/namespaces/default/configmaps/web"
+		snapshotRevision = "1111111111111111111111111111111111111111"
+	)
+
+	configMaps := map[string]*corev1.ConfigMap{
+		"dummycd-inventory-app": {
+			ObjectMeta: metav1.ObjectMeta{Name: "dummycd-inventory-app"},
+			Data:       map[string]string{inventoryDataKey: "[]"},
+		},
+	}
+
+	var mutex sync.Mutex
+	var applied []string
+
+	r := newTestRawProvider(RawActionOptions{Atomic: true, SelfHeal: true})
+	r.mapper, _ = newTestResourceMapper(testAPIResources)
+	r.mutex = new(sync.Mutex)
+	r.inventoryMutex = new(sync.Mutex)
+	r.clientSet = newTestConfigMapClientSet(t, configMaps)
+	r.labels = map[string]string{"dummy.cd/app": "app"}
+	r.dynamicClient = newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if req.Method == http.MethodPatch {
+			applied = append(applied, req.URL.Path)
+		}
+
+		if req.URL.Path != webPath {
+			writeTestStatus(w, http.StatusNotFound, "NotFound")
+			return
+		}
+
+		// the object of the snapshot drifted
+		_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "web", "namespace": "default", ` +
+			`"labels": {"dummy.cd/app": "app", "dummy.cd/revision": "` + snapshotRevision + `"}}, "data": {"key": "b"}}`))
+	})
+
+	revision := plumbing.NewHash(snapshotRevision)
+	r.appRevision = &revision
+
+	if err := r.saveSnapshot([]*Resource{{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: web}\ndata: {key: a}\n")}}); err != nil {
+		t.Fatal(err)
+	}
+
+	r.appRevision = &plumbing.ZeroHash
+
+	if err := r.setRolledBack(r.appRevision.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	r.resources = []*Resource{
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: failed}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: Pod\nmetadata: {name: migrate, annotations: {dummy.cd/hook: PreSync}}\n")},
+	}
+
+	if err := r.Delivery(); !errors.Is(err, util.ErrRevisionRolledBack) {
+		t.Fatalf("Delivery() error = %v, want %v", err, util.ErrRevisionRolledBack)
+	}
+
+	r.mutex.Lock()
+	r.mutex.Unlock()
+
+	if fmt.Sprint(applied) != "["+webPath+"]" {
+		t.Errorf("Delivery() applied %v, want only self-healed object of the snapshot", applied)
+	}
+
+	if revision, _, err := r.getSnapshot(); err != nil || revision != snapshotRevision {
+		t.Errorf("getSnapshot() = %s, %v, want snapshot kept", revision, err)
+	}
+
+	if rolledBack, err := r.isRolledBack(r.appRevision.String()); err != nil || !rolledBack {
+		t.Errorf("isRolledBack() = %v, %v, want rolled back revision kept", rolledBack, err)
+	}
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// newResourceInterface returns dynamic client of the resolved resource
+func newResourceInterface(dynamicClient dynamic.Interface, resource *Resource) dynamic.ResourceInterface {
+	if resource.namespaced {
+		return dynamicClient.Resource(resource.gvr).Namespace(resource.namespace)
+	}
+
+	return dynamicClient.Resource(resource.gvr)
+}
+
+// getLiveObject returns live object of the resolved resource, nil if the object does not exist
+func getLiveObject(ctx context.Context, dynamicClient dynamic.Interface, resource *Resource) (*unstructured.Unstructured, error) {
+	live, err := newResourceInterface(dynamicClient, resource).Get(ctx, resource.obj.GetName(), metav1.GetOptions{})
+
+	if k8sErrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return live, err
+}
+
+// resolveResources resolves every resource, namespaced resources without namespace get the default one
+func resolveResources(mapper *ResourceMapper, resources []*Resource, defaultNamespace string) error {
+	for _, resource := range resources {
+		if err := resource.resolve(mapper, defaultNamespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newResourceDiff(resource *Resource, status SyncStatus, message string) *ResourceDiff {
+	return &ResourceDiff{
+		APIVersion: resource.obj.GetAPIVersion(),
+		Kind:       resource.obj.GetKind(),
+		Namespace:  resource.namespace,
+		Name:       resource.obj.GetName(),
+		Status:     status,
+		Message:    message,
+	}
+}
+
+// newResourceHealth returns health of the live object assessed by its kind
+func newResourceHealth(ctx context.Context, dynamicClient dynamic.Interface, resource *Resource, live *unstructured.Unstructured) (*ResourceHealth, error) {
+	status, message, err := GetResourceHealth(ctx, dynamicClient, live)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResourceHealth{
+		APIVersion: resource.obj.GetAPIVersion(),
+		Kind:       resource.obj.GetKind(),
+		Namespace:  resource.namespace,
+		Name:       resource.obj.GetName(),
+		Status:     status,
+		Message:    message,
+	}, nil
+}
+
+// getResourcesHealth returns health of live objects of the resolved resources
+func getResourcesHealth(ctx context.Context, dynamicClient dynamic.Interface, resources []*Resource) ([]*ResourceHealth, error) {
+	var health []*ResourceHealth
+
+	for _, resource := range resources {
+		live, err := getLiveObject(ctx, dynamicClient, resource)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resourceHealth, err := newResourceHealth(ctx, dynamicClient, resource, live)
+
+		if err != nil {
+			return nil, err
+		}
+
+		health = append(health, resourceHealth)
+	}
+
+	return health, nil
+}
+
+func getResourcesObjects(resources []*Resource) []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+
+	for _, resource := range resources {
+		objs = append(objs, resource.obj)
+	}
+
+	return objs
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sync"
+)
+
+// validateResources checks every resource with server-side dry-run apply before anything is written,
+// resources of CRDs and Namespaces created in the same sync can not be checked and are skipped
+func (r *RawProvider) validateResources(resources []*Resource) ([]*ResourceResult, error) {
+	definedKinds := make(map[schema.GroupKind]bool)
+	createdNamespaces := make(map[string]bool)
+
+	for _, resource := range resources {
+		switch resource.obj.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+			group, _, _ := unstructured.NestedString(resource.obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(resource.obj.Object, "spec", "names", "kind")
+			definedKinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		case schema.GroupKind{Kind: "Namespace"}:
+			createdNamespaces[resource.obj.GetName()] = true
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	results := make([]*ResourceResult, len(resources))
+	blocker := make(chan struct{}, 10)
+
+	for i, resource := range resources {
+		blocker <- struct{}{}
+		wg.Add(1)
+		go func(i int, resource *Resource) {
+			defer wg.Done()
+			defer func() { <-blocker }()
+			results[i] = r.validateResource(resource, definedKinds, createdNamespaces)
+		}(i, resource)
+	}
+
+	wg.Wait()
+
+	var failed []*ResourceResult
+	var errs []error
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+			errs = append(errs, result.Err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return failed, fmt.Errorf("%w: %d of %d objects, nothing is applied: %w", util.ErrValidationFailed, len(errs), len(resources), errors.Join(errs...))
+	}
+
+	return nil, nil
+}
+
+func (r *RawProvider) validateResource(resource *Resource, definedKinds map[schema.GroupKind]bool, createdNamespaces map[string]bool) *ResourceResult {
+	groupKind := resource.obj.GroupVersionKind().GroupKind()
+
+	if err := resource.resolve(r.mapper, *r.namespace); err != nil {
+		if meta.IsNoMatchError(err) && definedKinds[groupKind] {
+			return newResourceResult(resource, ResourceOperationUnchanged, "validation skipped, CRD is applied in the same sync")
+		}
+
+		return r.failValidation(resource, err)
+	}
+
+	if err := r.checkAllowed(groupKind, resource.namespaced, resource.namespace); err != nil {
+		return r.failValidation(resource, err)
+	}
+
+	live, err := getLiveObject(r.ctx, r.dynamicClient, resource)
+
+	if err != nil {
+		return r.failValidation(resource, err)
+	}
+
+	if live != nil {
+		if err := r.checkOwner(live); err != nil {
+			return r.failValidation(resource, err)
+		}
+	}
+
+	obj := resource.obj.DeepCopy()
+	setTrackingLabels(obj, r.labels)
+
+	_, err = r.resourceInterface(resource).Apply(r.ctx, obj.GetName(), obj, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        r.isForceApply(obj, live),
+		DryRun:       []string{metav1.DryRunAll},
+	})
+
+	switch {
+	case err == nil:
+		return newResourceResult(resource, ResourceOperationUnchanged, "validated")
+	case k8sErrors.IsNotFound(err) && createdNamespaces[resource.namespace]:
+		return newResourceResult(resource, ResourceOperationUnchanged, "validation skipped, namespace is created in the same sync")
+	case isImmutableFieldError(err) && r.isReplaceAllowed(resource):
+		return newResourceResult(resource, ResourceOperationUnchanged, "validated, the object is replaced")
+	case IsHook(obj) && isImmutableFieldError(err):
+		return newResourceResult(resource, ResourceOperationUnchanged, "validated, the hook is created again")
+	}
+
+	return r.failValidation(resource, err)
+}
+
+func (r *RawProvider) failValidation(resource *Resource, err error) *ResourceResult {
+	return r.failResource(resource, fmt.Errorf("validation failed: %w", err))
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestIsForceApply(t *testing.T) {
+	const (
+		desired = "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {dummy.cd/app: app, dummy.cd/revision: r2}}\ndata: {key: a}\n"
+		hook    = "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a, annotations: {dummy.cd/hook: pre-sync}}\n"
+	)
+
+	tests := []struct {
+		name     string
+		options  RawActionOptions
+		desired  string
+		live     string
+		revision string
+		want     bool
+	}{
+		{
+			name:    "created",
+			desired: desired,
+		},
+		{
+			name:    "force conflicts",
+			options: RawActionOptions{ForceConflicts: true},
+			desired: desired,
+			live:    "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {dummy.cd/app: app, dummy.cd/revision: r1}}\n",
+			want:    true,
+		},
+		{
+			name:    "hook",
+			desired: hook,
+			want:    true,
+		},
+		{
+			name:    "adopted",
+			desired: desired,
+			live:    "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\ndata: {key: a}\n",
+			want:    true,
+		},
+		{
+			name:     "new revision",
+			options:  RawActionOptions{SelfHeal: true},
+			desired:  desired,
+			live:     "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {dummy.cd/app: app, dummy.cd/revision: r1}}\ndata: {key: b}\n",
+			revision: "r2",
+		},
+		{
+			name:     "self-heal drift",
+			options:  RawActionOptions{SelfHeal: true},
+			desired:  desired,
+			live:     "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {dummy.cd/app: app, dummy.cd/revision: r2}}\ndata: {key: b}\n",
+			revision: "r2",
+			want:     true,
+		},
+		{
+			name:     "drift without self-heal",
+			desired:  desired,
+			live:     "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a, labels: {dummy.cd/app: app, dummy.cd/revision: r2}}\ndata: {key: b}\n",
+			revision: "r2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appName := "app"
+			options := tt.options
+
+			r := &RawProvider{
+				ActionOptions: &options,
+				appName:       &appName,
+				labels:        map[string]string{"dummy.cd/app": appName, "dummy.cd/revision": tt.revision},
+			}
+
+			var live *unstructured.Unstructured
+
+			if len(tt.live) > 0 {
+				live = newTestObject(t, tt.live)
+			}
+
+			if got := r.isForceApply(newTestObject(t, tt.desired), live); got != tt.want {
+				t.Errorf("isForceApply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResources(t *testing.T) {
+	mapper, _ := newTestResourceMapper(testAPIResources)
+
+	var dryRuns []string
+	var mutex sync.Mutex
+
+	r := newTestRawProvider(RawActionOptions{AllowedNamespaces: []string{"*"}, AllowedClusterResources: []string{"*"}})
+	r.mapper = mapper
+	r.labels = map[string]string{"dummy.cd/app": "app", "dummy.cd/revision": "r2"}
+	r.dynamicClient = newTestDynamicClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPatch {
+			writeTestStatus(w, http.StatusNotFound, "NotFound")
+			return
+		}
+
+		mutex.Lock()
+		dryRuns = append(dryRuns, path.Base(req.URL.Path)+" "+req.URL.Query().Get("dryRun"))
+		mutex.Unlock()
+
+		switch {
+		case strings.Contains(req.URL.Path, "/namespaces/new/"):
+			writeTestStatus(w, http.StatusNotFound, "NotFound")
+		case path.Base(req.URL.Path) == "invalid":
+			writeTestStatus(w, http.StatusUnprocessableEntity, "Invalid")
+		default:
+			_, _ = w.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}`))
+		}
+	})
+
+	resources := []*Resource{
+		{obj: newTestObject(t, "apiVersion: v1\nkind: Namespace\nmetadata: {name: new}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: in-new-namespace, namespace: new}\n")},
+		{obj: newTestObject(t, "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata: {name: widgets.example.com}\n"+
+			"spec: {group: example.com, names: {kind: Widget}}\n")},
+		{obj: newTestObject(t, "apiVersion: example.com/v1\nkind: Widget\nmetadata: {name: widget}\n")},
+		{obj: newTestObject(t, "apiVersion: example.com/v1\nkind: Gadget\nmetadata: {name: gadget}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: invalid}\n")},
+		{obj: newTestObject(t, "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: valid}\n")},
+	}
+
+	failed, err := r.validateResources(resources)
+
+	if !errors.Is(err, util.ErrValidationFailed) {
+		t.Fatalf("validateResources() error = %v, want %v", err, util.ErrValidationFailed)
+	}
+
+	var got []string
+
+	for _, result := range failed {
+		got = append(got, result.Name)
+	}
+
+	if fmt.Sprint(got) != "[gadget invalid]" {
+		t.Errorf("validateResources() failed = %v, want [gadget invalid]", got)
+	}
+
+	sort.Strings(dryRuns)
+
+	if want := "[in-new-namespace All invalid All new All valid All widgets.example.com All]"; fmt.Sprint(dryRuns) != want {
+		t.Errorf("validateResources() dry-runs = %v, want %s", dryRuns, want)
+	}
+}
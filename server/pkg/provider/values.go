@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ValuesFromSecret    = "Secret"
+	ValuesFromConfigMap = "ConfigMap"
+
+	// defaultValuesKey is the key of the referenced object used when the key is not set
+	defaultValuesKey = "values.yaml"
+)
+
+// HelmValuesReference references values document in the key of the Secret or ConfigMap of the application namespace
+type HelmValuesReference struct {
+	Kind string
+	Name string
+	Key  string
+	// Optional reference is skipped when the object or the key does not exist
+	Optional bool
+}
+
+// getReferencedValues returns values document of the reference, nil if the optional reference is not found
+func (h *HelmProvider) getReferencedValues(reference HelmValuesReference) (map[string]interface{}, error) {
+	key := reference.Key
+
+	if len(key) == 0 {
+		key = defaultValuesKey
+	}
+
+	var data []byte
+	var found bool
+
+	switch reference.Kind {
+	case ValuesFromSecret:
+		secret, err := h.clientSet.CoreV1().Secrets(*h.namespace).Get(context.Background(), reference.Name, metav1.GetOptions{})
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			data, found = secret.Data[key]
+		}
+	case ValuesFromConfigMap:
+		configMap, err := h.clientSet.CoreV1().ConfigMaps(*h.namespace).Get(context.Background(), reference.Name, metav1.GetOptions{})
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			var value string
+
+			if value, found = configMap.Data[key]; found {
+				data = []byte(value)
+			} else {
+				data, found = configMap.BinaryData[key]
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", util.ErrUnsupportedValuesSource, reference.Kind)
+	}
+
+	if !found {
+		if reference.Optional {
+			h.logWithFields().Debugf("optional values %s %s key %s not found", reference.Kind, reference.Name, key)
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%w: %s %s/%s key %s", util.ErrValuesNotFound, reference.Kind, *h.namespace, reference.Name, key)
+	}
+
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%s %s key %s: %w", reference.Kind, reference.Name, key, err)
+	}
+
+	return values, nil
+}
+
+// getChartValues merges value files, documents of valuesFrom references in their order, inline values
+// and parameters with the runtime overrides, the later source takes precedence
+func (h *HelmProvider) getChartValues(parameterOverrides []HelmParameter) (map[string]interface{}, error) {
+	var overrides []map[string]interface{}
+
+	for _, reference := range h.ValuesFrom {
+		values, err := h.getReferencedValues(reference)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if values != nil {
+			overrides = append(overrides, values)
+		}
+	}
+
+	if len(h.Values) > 0 {
+		values := make(map[string]interface{})
+
+		if err := yaml.Unmarshal([]byte(h.Values), &values); err != nil {
+			return nil, fmt.Errorf("inline values: %w", err)
+		}
+
+		overrides = append(overrides, values)
+	}
+
+	parameters, err := newParametersOptions(mergeParameters(h.Parameters, parameterOverrides), *h.chartPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewHelmChartValues(h.settings, &h.valueFiles, parameters, overrides...)
+}
+
+// mergeValues merges src into dst, nested maps are merged and other values of src replace the ones of dst
+func mergeValues(dst map[string]interface{}, src map[string]interface{}) map[string]interface{} {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]interface{}); ok {
+			if dstMap, ok := dst[key].(map[string]interface{}); ok {
+				dst[key] = mergeValues(dstMap, srcMap)
+				continue
+			}
+		}
+
+		dst[key] = value
+	}
+
+	return dst
+}
+
+// isValuesEqual compares values of the release with the wanted ones regardless of numeric types
+func isValuesEqual(a map[string]interface{}, b map[string]interface{}) (bool, error) {
+	if len(a) == 0 && len(b) == 0 {
+		return true, nil
+	}
+
+	aJSON, err := json.Marshal(a)
+
+	if err != nil {
+		return false, err
+	}
+
+	bJSON, err := json.Marshal(b)
+
+	if err != nil {
+		return false, err
+	}
+
+	return string(aJSON) == string(bJSON), nil
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"testing"
+)
+
+func newTestValues(t *testing.T, document string) map[string]interface{} {
+	t.Helper()
+
+	values := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(document), &values); err != nil {
+		t.Fatal(err)
+	}
+
+	return values
+}
+
+func TestMergeValues(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  string
+		src  string
+		want string
+	}{
+		{
+			name: "nested maps are merged",
+			dst:  "image: {repository: nginx, tag: '1.0'}\nreplicas: 1\n",
+			src:  "image: {tag: '2.0'}\n",
+			want: "image: {repository: nginx, tag: '2.0'}\nreplicas: 1\n",
+		},
+		{
+			name: "lists are replaced",
+			dst:  "hosts: [a, b]\n",
+			src:  "hosts: [c]\n",
+			want: "hosts: [c]\n",
+		},
+		{
+			name: "map replaces scalar",
+			dst:  "resources: null\n",
+			src:  "resources: {limits: {cpu: 1}}\n",
+			want: "resources: {limits: {cpu: 1}}\n",
+		},
+		{
+			name: "scalar replaces map",
+			dst:  "ingress: {enabled: true}\n",
+			src:  "ingress: false\n",
+			want: "ingress: false\n",
+		},
+		{
+			name: "empty source",
+			dst:  "replicas: 1\n",
+			want: "replicas: 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeValues(newTestValues(t, tt.dst), newTestValues(t, tt.src))
+
+			if want := newTestValues(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergeValues() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIsValuesEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    map[string]interface{}
+		b    map[string]interface{}
+		want bool
+	}{
+		{"both empty", nil, map[string]interface{}{}, true},
+		{"numeric types", map[string]interface{}{"replicas": int64(2)}, map[string]interface{}{"replicas": float64(2)}, true},
+		{"nested", map[string]interface{}{"a": map[string]interface{}{"b": "c"}}, map[string]interface{}{"a": map[string]interface{}{"b": "c"}}, true},
+		{"changed", map[string]interface{}{"replicas": 2}, map[string]interface{}{"replicas": 3}, false},
+		{"string and number", map[string]interface{}{"tag": "1"}, map[string]interface{}{"tag": 1}, false},
+		{"empty and set", nil, map[string]interface{}{"replicas": 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isValuesEqual(tt.a, tt.b)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("isValuesEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
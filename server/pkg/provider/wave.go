@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// SyncWaveAnnotation orders delivery of raw resources, lower waves are applied and become ready first
+const SyncWaveAnnotation = "dummy.cd/sync-wave"
+
+var (
+	syncWaveTimeout  = flag.Duration("sync-wave-timeout", 5*time.Minute, "timeout to wait for resources of a sync wave to become ready or deleted")
+	syncWaveInterval = flag.Duration("sync-wave-interval", 2*time.Second, "interval to check resources of a sync wave")
+)
+
+// syncStep holds resources of the same sync wave and kind order, applied together
+type syncStep struct {
+	wave      int
+	kind      int
+	resources []*Resource
+}
+
+// GetSyncWave returns sync wave of the object annotation, 0 if it is not set or invalid
+func GetSyncWave(obj *unstructured.Unstructured) int {
+	value, exist := obj.GetAnnotations()[SyncWaveAnnotation]
+
+	if !exist {
+		return 0
+	}
+
+	wave, err := strconv.Atoi(value)
+
+	if err != nil {
+		log.Warnf("invalid %s annotation %q of %s %s, wave 0 is used", SyncWaveAnnotation, value, obj.GetKind(), obj.GetName())
+		return 0
+	}
+
+	return wave
+}
+
+// getKindOrder returns index of the kind in the order, unknown kinds like custom resources are ordered after known ones
+func getKindOrder(kind string, order releaseutil.KindSortOrder) int {
+	for i, k := range order {
+		if k == kind {
+			return i
+		}
+	}
+
+	return len(order)
+}
+
+// newSyncSteps groups resources by sync wave and kind ordered by releaseutil.InstallOrder,
+// uninstall reverses waves and uses releaseutil.UninstallOrder with unknown kinds first
+func newSyncSteps(resources []*Resource, uninstall bool) []*syncStep {
+	steps := make(map[[2]int]*syncStep)
+
+	for _, resource := range resources {
+		wave := GetSyncWave(resource.obj)
+
+		var kind int
+
+		if uninstall {
+			wave = -wave
+			kind = getKindOrder(resource.obj.GetKind(), releaseutil.UninstallOrder)
+
+			if kind == len(releaseutil.UninstallOrder) {
+				kind = -1
+			}
+		} else {
+			kind = getKindOrder(resource.obj.GetKind(), releaseutil.InstallOrder)
+		}
+
+		key := [2]int{wave, kind}
+
+		if _, exist := steps[key]; !exist {
+			steps[key] = &syncStep{wave: wave, kind: kind}
+		}
+
+		steps[key].resources = append(steps[key].resources, resource)
+	}
+
+	var ordered []*syncStep
+
+	for _, step := range steps {
+		ordered = append(ordered, step)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].wave != ordered[j].wave {
+			return ordered[i].wave < ordered[j].wave
+		}
+
+		return ordered[i].kind < ordered[j].kind
+	})
+
+	return ordered
+}
+
+// requiresReady reports whether resources of the next steps of the same wave depend on the step resources
+func (s *syncStep) requiresReady() bool {
+	for _, resource := range s.resources {
+		switch resource.obj.GetKind() {
+		case "Namespace", "CustomResourceDefinition":
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *syncStep) hasKind(kind string) bool {
+	for _, resource := range s.resources {
+		if resource.obj.GetKind() == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isResourceReady reports whether resources of the next steps can rely on the live object, CRDs have to be established,
+// Namespaces active, workloads rolled out and Jobs completed, objects of other kinds are ready once they exist
+func isResourceReady(ctx context.Context, dynamicClient dynamic.Interface, live *unstructured.Unstructured) (bool, error) {
+	gvk := live.GroupVersionKind()
+
+	switch {
+	case gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition":
+	case gvk.Group == "" && (gvk.Kind == "Namespace" || gvk.Kind == "Pod"):
+	case gvk.Group == "apps" && (gvk.Kind == "Deployment" || gvk.Kind == "StatefulSet" || gvk.Kind == "DaemonSet"):
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+	default:
+		return true, nil
+	}
+
+	status, message, err := GetResourceHealth(ctx, dynamicClient, live)
+
+	if err != nil {
+		return false, err
+	}
+
+	// failed Jobs and stuck rollouts never become ready, the next waves are not applied
+	if status == HealthStatusDegraded {
+		return false, fmt.Errorf("%w: %s", util.ErrResourceDegraded, message)
+	}
+
+	return status == HealthStatusHealthy, nil
+}
+
+// hasCondition reports whether status.conditions of the object contains the condition with the status
+func hasCondition(obj *unstructured.Unstructured, conditionType string, status string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+
+		if ok && condition["type"] == conditionType && condition["status"] == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitResources polls resolved resources until the condition is met for all of them or timeout is reached
+func waitResources(ctx context.Context, dynamicClient dynamic.Interface, resources []*Resource,
+	timeout time.Duration, condition func(ctx context.Context, live *unstructured.Unstructured) (bool, error)) error {
+
+	var pending *Resource
+
+	err := wait.PollUntilContextTimeout(ctx, *syncWaveInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, resource := range resources {
+			if len(resource.gvr.Resource) == 0 {
+				continue
+			}
+
+			live, err := getLiveObject(ctx, dynamicClient, resource)
+
+			if err != nil {
+				return false, err
+			}
+
+			done, err := condition(ctx, live)
+
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", resource, err)
+			}
+
+			if !done {
+				pending = resource
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+
+	if err != nil && pending != nil {
+		return fmt.Errorf("%w: waiting for %s", err, pending)
+	}
+
+	return err
+}
+
+// waitReady waits for the resources to exist and become ready
+func waitReady(ctx context.Context, dynamicClient dynamic.Interface, resources []*Resource) error {
+	return waitResources(ctx, dynamicClient, resources, *syncWaveTimeout, func(ctx context.Context, live *unstructured.Unstructured) (bool, error) {
+		if live == nil {
+			return false, nil
+		}
+
+		return isResourceReady(ctx, dynamicClient, live)
+	})
+}
+
+// waitDeleted waits for the resources to be removed
+func waitDeleted(ctx context.Context, dynamicClient dynamic.Interface, resources []*Resource) error {
+	return waitResources(ctx, dynamicClient, resources, *syncWaveTimeout, func(ctx context.Context, live *unstructured.Unstructured) (bool, error) {
+		return live == nil, nil
+	})
+}
+
+// waitHealthy waits for the resources to become healthy, degraded resources fail the wait immediately
+func waitHealthy(ctx context.Context, dynamicClient dynamic.Interface, resources []*Resource, timeout time.Duration) error {
+	return waitResources(ctx, dynamicClient, resources, timeout, func(ctx context.Context, live *unstructured.Unstructured) (bool, error) {
+		status, message, err := GetResourceHealth(ctx, dynamicClient, live)
+
+		if err != nil {
+			return false, err
+		}
+
+		if status == HealthStatusDegraded {
+			return false, fmt.Errorf("%w: %s", util.ErrResourceDegraded, message)
+		}
+
+		return status == HealthStatusHealthy, nil
+	})
+}
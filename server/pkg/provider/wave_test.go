@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/yimgzz/dummy-cd/server/pkg/util"
+	"testing"
+)
+
+// newTestResource returns resource of the object of the kind in the sync wave
+func newTestResource(t *testing.T, kind string, name string, wave string) *Resource {
+	t.Helper()
+
+	manifest := fmt.Sprintf("apiVersion: v1\nkind: %s\nmetadata:\n  name: %s\n", kind, name)
+
+	if len(wave) > 0 {
+		manifest += fmt.Sprintf("  annotations:\n    %s: %q\n", SyncWaveAnnotation, wave)
+	}
+
+	return &Resource{obj: newTestObject(t, manifest)}
+}
+
+func TestGetSyncWave(t *testing.T) {
+	tests := []struct {
+		wave string
+		want int
+	}{
+		{"", 0},
+		{"-2", -2},
+		{"5", 5},
+		{"invalid", 0},
+	}
+
+	for _, tt := range tests {
+		if got := GetSyncWave(newTestResource(t, "ConfigMap", "a", tt.wave).obj); got != tt.want {
+			t.Errorf("GetSyncWave(%q) = %d, want %d", tt.wave, got, tt.want)
+		}
+	}
+}
+
+func getStepNames(steps []*syncStep) [][]string {
+	var names [][]string
+
+	for _, step := range steps {
+		var stepNames []string
+
+		for _, resource := range step.resources {
+			stepNames = append(stepNames, resource.obj.GetName())
+		}
+
+		names = append(names, stepNames)
+	}
+
+	return names
+}
+
+func TestNewSyncSteps(t *testing.T) {
+	resources := []*Resource{
+		newTestResource(t, "Deployment", "app", ""),
+		newTestResource(t, "Widget", "custom", ""),
+		newTestResource(t, "ConfigMap", "config", ""),
+		newTestResource(t, "Job", "migration", "-1"),
+		newTestResource(t, "Namespace", "ns", ""),
+		newTestResource(t, "ConfigMap", "late", "1"),
+		newTestResource(t, "Secret", "secret", "-1"),
+	}
+
+	tests := []struct {
+		name      string
+		uninstall bool
+		want      [][]string
+	}{
+		{
+			name: "install",
+			want: [][]string{{"secret"}, {"migration"}, {"ns"}, {"config"}, {"app"}, {"custom"}, {"late"}},
+		},
+		{
+			name:      "uninstall",
+			uninstall: true,
+			want:      [][]string{{"late"}, {"custom"}, {"app"}, {"config"}, {"ns"}, {"migration"}, {"secret"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getStepNames(newSyncSteps(resources, tt.uninstall))
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("newSyncSteps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncStepRequiresReady(t *testing.T) {
+	for _, tt := range []struct {
+		kind string
+		want bool
+	}{
+		{"Namespace", true},
+		{"CustomResourceDefinition", true},
+		{"ConfigMap", false},
+	} {
+		step := &syncStep{resources: []*Resource{newTestResource(t, tt.kind, "a", "")}}
+
+		if got := step.requiresReady(); got != tt.want {
+			t.Errorf("requiresReady() of %s = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestIsResourceReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     bool
+		wantErr  error
+	}{
+		{
+			name:     "config map exists",
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata: {name: a}\n",
+			want:     true,
+		},
+		{
+			name:     "namespace terminating",
+			manifest: "apiVersion: v1\nkind: Namespace\nmetadata: {name: a}\nstatus: {phase: Terminating}\n",
+		},
+		{
+			name:     "namespace active",
+			manifest: "apiVersion: v1\nkind: Namespace\nmetadata: {name: a}\nstatus: {phase: Active}\n",
+			want:     true,
+		},
+		{
+			name: "crd not established",
+			manifest: "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata: {name: a}\n" +
+				"status: {conditions: [{type: Established, status: 'False'}]}\n",
+		},
+		{
+			name: "deployment rolling out",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, generation: 2}\nspec: {replicas: 2}\n" +
+				"status: {observedGeneration: 2, replicas: 2, updatedReplicas: 1, availableReplicas: 1}\n",
+		},
+		{
+			name: "deployment rolled out",
+			manifest: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {name: a, generation: 2}\nspec: {replicas: 2}\n" +
+				"status: {observedGeneration: 2, replicas: 2, updatedReplicas: 2, availableReplicas: 2}\n",
+			want: true,
+		},
+		{
+			name:     "job running",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {active: 1}\n",
+		},
+		{
+			name:     "job complete",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {conditions: [{type: Complete, status: 'True'}]}\n",
+			want:     true,
+		},
+		{
+			name:     "job failed",
+			manifest: "apiVersion: batch/v1\nkind: Job\nmetadata: {name: a}\nstatus: {conditions: [{type: Failed, status: 'True', message: backoff}]}\n",
+			wantErr:  util.ErrResourceDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isResourceReady(context.Background(), nil, newTestObject(t, tt.manifest))
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("isResourceReady() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("isResourceReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
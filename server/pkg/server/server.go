@@ -8,6 +8,8 @@ import (
 	"github.com/yimgzz/dummy-cd/server/pkg/provider"
 	"github.com/yimgzz/dummy-cd/server/pkg/util"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+	"time"
 )
 
 type Server struct {
@@ -90,16 +92,59 @@ func (s *Server) AddOrUpdateApplication(ctx context.Context, in *pb.Application)
 		Reference:        in.GetReference(),
 		URL:              in.GetUrl(),
 		SparsePath:       in.GetSparsePath(),
+		SparsePaths:      in.GetSparsePaths(),
+		Include:          in.GetInclude(),
+		Exclude:          in.GetExclude(),
 		Helm: &provider.HelmProvider{
 			ValueFiles: in.GetHelm().GetValuesFiles(),
+			ValuesFrom: getHelmValuesFrom(in.GetHelm().GetValuesFrom()),
+			Values:     in.GetHelm().GetValues(),
+			Parameters: getHelmParameters(in.GetHelm().GetParameters()),
+			PostRender: provider.HelmPostRender{
+				Kustomize: in.GetHelm().GetPostRender().GetKustomize(),
+				Plugin:    in.GetHelm().GetPostRender().GetPlugin(),
+				Args:      in.GetHelm().GetPostRender().GetArgs(),
+			},
 			ActionOptions: &provider.HelmActionOptions{
 				CheckValuesEqual: in.GetHelm().GetCheckValuesEqual(),
 				ReInstallRelease: in.GetHelm().GetReInstallRelease(),
 				CreateNamespace:  in.GetHelm().GetCreateNamespace(),
 				Atomic:           in.GetHelm().GetAtomic(),
 				IncludeCRDs:      in.GetHelm().GetIncludeCRDs(),
+				SelfHeal:         in.GetHelm().GetSelfHeal(),
+			},
+		},
+		Raw: &provider.RawProvider{
+			ActionOptions: &provider.RawActionOptions{
+				RecurseDepth:            int(in.GetRaw().GetRecurseDepth()),
+				AllowedNamespaces:       in.GetRaw().GetAllowedNamespaces(),
+				AllowedClusterResources: in.GetRaw().GetAllowedClusterResources(),
+				ForceConflicts:          in.GetRaw().GetForceConflicts(),
+				SelfHeal:                in.GetRaw().GetSelfHeal(),
+				WaitHealthy:             in.GetRaw().GetWaitHealthy(),
+				HealthTimeout:           time.Duration(in.GetRaw().GetHealthTimeoutSeconds()) * time.Second,
+				Prune:                   in.GetRaw().GetPrune(),
+				PruneLimitPercent:       int(in.GetRaw().GetPruneLimitPercent()),
+				AllowAdoption:           in.GetRaw().GetAllowAdoption(),
+				Replace:                 in.GetRaw().GetReplace(),
+				ReplaceWait:             in.GetRaw().GetReplaceWait(),
+				Validate:                in.GetRaw().GetValidate(),
+				Atomic:                  in.GetRaw().GetAtomic(),
 			},
 		},
+		Jsonnet: &provider.JsonnetProvider{
+			Entrypoint: in.GetJsonnet().GetEntrypoint(),
+			JPaths:     in.GetJsonnet().GetJpaths(),
+			ExtVars:    getJsonnetVariables(in.GetJsonnet().GetExtVars()),
+			TLAs:       getJsonnetVariables(in.GetJsonnet().GetTlas()),
+		},
+		Provider: in.GetProvider(),
+		Plugin: &provider.PluginProvider{
+			Name: in.GetPlugin().GetName(),
+			Args: in.GetPlugin().GetArgs(),
+			Env:  getPluginEnv(in.GetPlugin().GetEnv()),
+		},
+		IgnoreDifferences: getIgnoreDifferences(in.GetIgnoreDifferences()),
 	})
 
 	if err != nil {
@@ -193,3 +238,273 @@ func (s *Server) CheckoutApplicationRevision(ctx context.Context, in *pb.Applica
 
 	return &pb.Empty{}, nil
 }
+
+func (s *Server) RenderApplication(ctx context.Context, in *pb.Application) (*pb.Manifests, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.Manifests{}, util.ErrApplicationNotFound
+	}
+
+	objs, err := app.Render()
+
+	if err != nil {
+		return &pb.Manifests{}, err
+	}
+
+	var manifests []string
+
+	for _, obj := range objs {
+		manifest, err := yaml.Marshal(obj.Object)
+
+		if err != nil {
+			return &pb.Manifests{}, err
+		}
+
+		manifests = append(manifests, string(manifest))
+	}
+
+	return &pb.Manifests{Items: manifests}, nil
+}
+
+func (s *Server) DiffApplication(ctx context.Context, in *pb.Application) (*pb.ResourceDiffs, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.ResourceDiffs{}, util.ErrApplicationNotFound
+	}
+
+	appDiffs, err := app.Diff()
+
+	if err != nil {
+		return &pb.ResourceDiffs{}, err
+	}
+
+	var diffs []*pb.ResourceDiff
+
+	for _, d := range appDiffs {
+		diffs = append(diffs, &pb.ResourceDiff{
+			ApiVersion: d.APIVersion,
+			Kind:       d.Kind,
+			Namespace:  d.Namespace,
+			Name:       d.Name,
+			Status:     string(d.Status),
+			Message:    d.Message,
+			Fields:     d.Fields,
+		})
+	}
+
+	return &pb.ResourceDiffs{Items: diffs}, nil
+}
+
+func (s *Server) GetApplicationHealth(ctx context.Context, in *pb.Application) (*pb.ApplicationHealth, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.ApplicationHealth{}, util.ErrApplicationNotFound
+	}
+
+	appHealth, err := app.Health()
+
+	if err != nil {
+		return &pb.ApplicationHealth{}, err
+	}
+
+	var resources []*pb.ResourceHealth
+
+	for _, h := range appHealth.Resources {
+		resources = append(resources, &pb.ResourceHealth{
+			ApiVersion: h.APIVersion,
+			Kind:       h.Kind,
+			Namespace:  h.Namespace,
+			Name:       h.Name,
+			Status:     string(h.Status),
+			Message:    h.Message,
+		})
+	}
+
+	return &pb.ApplicationHealth{Status: string(appHealth.Status), Items: resources}, nil
+}
+
+func (s *Server) GetApplicationStatus(ctx context.Context, in *pb.Application) (*pb.ApplicationStatus, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.ApplicationStatus{}, util.ErrApplicationNotFound
+	}
+
+	appSync := app.Status()
+
+	status := &pb.ApplicationStatus{
+		Sync:     string(appSync.Status),
+		Revision: &pb.Revision{Hash: app.CurrentRevision.String()},
+	}
+
+	if !appSync.CheckedAt.IsZero() {
+		status.CheckedAt = appSync.CheckedAt.Format(time.RFC3339)
+	}
+
+	for _, d := range appSync.Resources {
+		status.Resources = append(status.Resources, &pb.ResourceDiff{
+			ApiVersion: d.APIVersion,
+			Kind:       d.Kind,
+			Namespace:  d.Namespace,
+			Name:       d.Name,
+			Status:     string(d.Status),
+			Message:    d.Message,
+			Fields:     d.Fields,
+		})
+	}
+
+	for _, d := range appSync.Prunes {
+		status.Prunes = append(status.Prunes, &pb.ResourceDiff{
+			ApiVersion: d.APIVersion,
+			Kind:       d.Kind,
+			Namespace:  d.Namespace,
+			Name:       d.Name,
+			Status:     string(d.Status),
+			Message:    d.Message,
+		})
+	}
+
+	for _, r := range appSync.Results {
+		status.Results = append(status.Results, &pb.ResourceResult{
+			ApiVersion: r.APIVersion,
+			Kind:       r.Kind,
+			Namespace:  r.Namespace,
+			Name:       r.Name,
+			Operation:  string(r.Operation),
+			Message:    r.Message,
+		})
+	}
+
+	for _, h := range appSync.History {
+		status.History = append(status.History, &pb.SyncRecord{
+			Revision:   h.Revision,
+			Status:     string(h.Status),
+			Message:    h.Message,
+			StartedAt:  h.StartedAt.Format(time.RFC3339),
+			FinishedAt: h.FinishedAt.Format(time.RFC3339),
+			Parameters: newHelmParameters(h.Parameters),
+		})
+	}
+
+	status.Parameters = newHelmParameters(appSync.Parameters)
+
+	return status, nil
+}
+
+func (s *Server) ConfirmApplicationPrune(ctx context.Context, in *pb.Application) (*pb.Empty, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.Empty{}, util.ErrApplicationNotFound
+	}
+
+	err := app.ConfirmPrune()
+
+	if err != nil {
+		log.Error(err)
+		return &pb.Empty{}, err
+	}
+
+	return &pb.Empty{}, nil
+}
+
+// OverrideApplicationParameters replaces helm parameters of the application overridden at runtime with the parameters of the request
+func (s *Server) OverrideApplicationParameters(ctx context.Context, in *pb.Application) (*pb.Empty, error) {
+	log.Debugf("request recieved: %+v", in)
+
+	app := s.Handler.GetApplication(in.GetName(), in.GetUrl())
+
+	if app == nil {
+		return &pb.Empty{}, util.ErrApplicationNotFound
+	}
+
+	err := app.OverrideParameters(getHelmParameters(in.GetHelm().GetParameters()))
+
+	if err != nil {
+		log.Error(err)
+		return &pb.Empty{}, err
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func getJsonnetVariables(in []*pb.JsonnetVariable) []provider.JsonnetVariable {
+	var variables []provider.JsonnetVariable
+
+	for _, v := range in {
+		variables = append(variables, provider.JsonnetVariable{Name: v.GetName(), Value: v.GetValue(), Code: v.GetCode()})
+	}
+
+	return variables
+}
+
+func getPluginEnv(in []*pb.PluginEnv) []provider.PluginEnv {
+	var env []provider.PluginEnv
+
+	for _, e := range in {
+		env = append(env, provider.PluginEnv{Name: e.GetName(), Value: e.GetValue()})
+	}
+
+	return env
+}
+
+func getIgnoreDifferences(in []*pb.IgnoreDifference) []provider.IgnoreDifference {
+	var differences []provider.IgnoreDifference
+
+	for _, d := range in {
+		differences = append(differences, provider.IgnoreDifference{
+			Group:                 d.GetGroup(),
+			Kind:                  d.GetKind(),
+			Name:                  d.GetName(),
+			Namespace:             d.GetNamespace(),
+			JSONPointers:          d.GetJsonPointers(),
+			JQPathExpressions:     d.GetJqPathExpressions(),
+			ManagedFieldsManagers: d.GetManagedFieldsManagers(),
+		})
+	}
+
+	return differences
+}
+
+func getHelmValuesFrom(in []*pb.HelmValuesReference) []provider.HelmValuesReference {
+	var references []provider.HelmValuesReference
+
+	for _, r := range in {
+		references = append(references, provider.HelmValuesReference{Kind: r.GetKind(), Name: r.GetName(), Key: r.GetKey(), Optional: r.GetOptional()})
+	}
+
+	return references
+}
+
+func getHelmParameters(in []*pb.HelmParameter) []provider.HelmParameter {
+	var parameters []provider.HelmParameter
+
+	for _, p := range in {
+		parameters = append(parameters, provider.HelmParameter{Name: p.GetName(), Value: p.GetValue(), Type: p.GetType()})
+	}
+
+	return parameters
+}
+
+func newHelmParameters(parameters []provider.HelmParameter) []*pb.HelmParameter {
+	var out []*pb.HelmParameter
+
+	for _, p := range parameters {
+		out = append(out, &pb.HelmParameter{Name: p.Name, Value: p.Value, Type: p.Type})
+	}
+
+	return out
+}
@@ -10,4 +10,23 @@ var (
 	ErrRepositoryConfigNotFound  = errors.New("repository config not found")
 	ErrApplicationAlreadyExist   = errors.New("application already exist")
 	ErrApplicationNotFound       = errors.New("application not found")
+	ErrNoResourceFiles           = errors.New("no one resource file found")
+	ErrInvalidResource           = errors.New("invalid resource")
+	ErrResourceNotAllowed        = errors.New("resource is not allowed for the application")
+	ErrPluginNotFound            = errors.New("plugin not found")
+	ErrProviderNotFound          = errors.New("delivery provider not found")
+	ErrResourceDegraded          = errors.New("resource is degraded")
+	ErrNoPendingPrunes           = errors.New("no objects pending prune")
+	ErrResourceOwnedByAnotherApp = errors.New("object is owned by another application")
+	ErrResourceNotManaged        = errors.New("object is not managed by dummycd")
+	ErrHookFailed                = errors.New("hook failed")
+	ErrValidationFailed          = errors.New("validation failed")
+	ErrNoKnownGoodRevision       = errors.New("no known-good revision to roll back to")
+	ErrRevisionRolledBack        = errors.New("revision was rolled back, waiting for a new revision")
+	ErrValuesNotFound            = errors.New("values not found")
+	ErrUnsupportedValuesSource   = errors.New("unsupported values source")
+	ErrInvalidParameter          = errors.New("invalid parameter")
+	ErrParametersNotSupported    = errors.New("delivery provider does not support parameters")
+	ErrInvalidPostRender         = errors.New("invalid post-render")
+	ErrPruneNotSupported         = errors.New("delivery provider does not support prune confirmation")
 )
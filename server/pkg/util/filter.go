@@ -0,0 +1,102 @@
+package util
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// FileFilter holds include and exclude glob patterns for application source files
+//
+// patterns without a separator are matched against the file name, other patterns
+// are matched against the path relative to the source path, "**" matches any number of directories
+type FileFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// NewFileFilter returns FileFilter with validated glob patterns
+func NewFileFilter(include []string, exclude []string) (*FileFilter, error) {
+	for _, pattern := range append(append([]string{}, include...), exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FileFilter{Include: include, Exclude: exclude}, nil
+}
+
+// Match reports whether the file with path relative to the source path passes include and exclude patterns
+func (f *FileFilter) Match(relPath string) bool {
+	if f == nil {
+		return true
+	}
+
+	relPath = filepath.ToSlash(relPath)
+
+	if len(f.Include) > 0 && !matchAnyGlob(f.Include, relPath) {
+		return false
+	}
+
+	return !matchAnyGlob(f.Exclude, relPath)
+}
+
+// IsSubPath reports whether path is root itself or located under root directory
+func IsSubPath(root string, path string) bool {
+	root = strings.Trim(filepath.ToSlash(root), "/")
+	path = strings.Trim(filepath.ToSlash(path), "/")
+
+	if len(root) == 0 || root == "." {
+		return true
+	}
+
+	return path == root || strings.HasPrefix(path, root+"/")
+}
+
+func matchAnyGlob(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if matchGlob(pattern, relPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchGlob(pattern string, relPath string) bool {
+	pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		matched, _ := filepath.Match(pattern, filepath.Base(relPath))
+		return matched
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchSegments(pattern []string, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(path) == 0 {
+			return false
+		}
+
+		matched, err := filepath.Match(pattern[0], path[0])
+
+		if err != nil || !matched {
+			return false
+		}
+
+		pattern = pattern[1:]
+		path = path[1:]
+	}
+
+	return len(path) == 0
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestFileFilterMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		path    string
+		want    bool
+	}{
+		{name: "no patterns", path: "app/deployment.yaml", want: true},
+		{name: "name pattern in subdirectory", include: []string{"*.yaml"}, path: "app/deployment.yaml", want: true},
+		{name: "name pattern not matched", include: []string{"*.yaml"}, path: "app/README.md"},
+		{name: "path pattern", include: []string{"app/*.yaml"}, path: "app/deployment.yaml", want: true},
+		{name: "path pattern of other level", include: []string{"app/*.yaml"}, path: "app/base/deployment.yaml"},
+		{name: "double star any depth", include: []string{"app/**/*.yaml"}, path: "app/a/b/deployment.yaml", want: true},
+		{name: "double star zero directories", include: []string{"app/**/*.yaml"}, path: "app/deployment.yaml", want: true},
+		{name: "double star prefix", include: []string{"**/base/*.yaml"}, path: "base/deployment.yaml", want: true},
+		{name: "double star alone", include: []string{"**"}, path: "a/b/c.yaml", want: true},
+		{name: "leading slash ignored", include: []string{"/app/*.yaml"}, path: "app/deployment.yaml", want: true},
+		{name: "excluded name", exclude: []string{"*-test.yaml"}, path: "app/deployment-test.yaml"},
+		{name: "excluded directory", include: []string{"*.yaml"}, exclude: []string{"tests/**"}, path: "tests/a/deployment.yaml"},
+		{name: "exclude takes precedence", include: []string{"app/*.yaml"}, exclude: []string{"app/*.yaml"}, path: "app/deployment.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewFileFilter(tt.include, tt.exclude)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := filter.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFileFilterInvalidPattern(t *testing.T) {
+	if _, err := NewFileFilter([]string{"[a-"}, nil); err == nil {
+		t.Error("NewFileFilter() with invalid pattern returned no error")
+	}
+}
+
+func TestNilFileFilterMatch(t *testing.T) {
+	var filter *FileFilter
+
+	if !filter.Match("a.yaml") {
+		t.Error("nil FileFilter does not match")
+	}
+}
+
+func TestIsSubPath(t *testing.T) {
+	tests := []struct {
+		root string
+		path string
+		want bool
+	}{
+		{"", "app", true},
+		{".", "app", true},
+		{"app", "app", true},
+		{"app", "app/base", true},
+		{"/app/", "app/base/", true},
+		{"app", "application", false},
+		{"app/base", "app", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSubPath(tt.root, tt.path); got != tt.want {
+			t.Errorf("IsSubPath(%q, %q) = %v, want %v", tt.root, tt.path, got, tt.want)
+		}
+	}
+}
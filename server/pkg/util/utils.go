@@ -1,11 +1,12 @@
 package util
 
 import (
-	log "github.com/sirupsen/logrus"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 )
 
 func GetUserHome() *string {
@@ -58,28 +59,63 @@ func GetFilesFullPath(path *string, fileNames *[]string) []string {
 	return results
 }
 
-func FindFilesWithRegex(root *string, regExpr string) []string {
+// FindFilesWithRegex returns sorted paths relative to root of files with name matched the regular expression,
+// maxDepth limits the number of directory levels to descend, files directly in root are on the first level, 0 is unlimited
+func FindFilesWithRegex(root *string, regExpr string, maxDepth int) ([]string, error) {
 	var results []string
 
 	rx, err := regexp.Compile(regExpr)
 
 	if err != nil {
-		log.Error(err)
-		return nil
+		return nil, err
 	}
 
-	err = filepath.Walk(*root,
-		func(path string, info fs.FileInfo, err error) error {
-			if err == nil && rx.MatchString(info.Name()) {
-				results = append(results, info.Name())
+	err = filepath.WalkDir(*root,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(*root, path)
+
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() {
+				if entry.Name() == ".git" {
+					return filepath.SkipDir
+				}
+
+				if relPath != "." && maxDepth > 0 && len(strings.Split(relPath, string(filepath.Separator))) >= maxDepth {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if rx.MatchString(entry.Name()) {
+				results = append(results, relPath)
 			}
+
 			return nil
 		})
 
 	if err != nil {
-		log.Error(err)
-		return nil
+		return nil, err
 	}
 
-	return results
+	sort.Strings(results)
+
+	return results, nil
+}
+
+func ContainsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
 }
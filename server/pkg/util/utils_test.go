@@ -0,0 +1,67 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindFilesWithRegex(t *testing.T) {
+	root := t.TempDir()
+
+	for _, file := range []string{
+		"a.yaml",
+		"README.md",
+		"base/b.yaml",
+		"base/nested/c.yml",
+		"base/nested/deep/d.yaml",
+		".git/e.yaml",
+	} {
+		path := filepath.Join(root, file)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		maxDepth int
+		want     []string
+	}{
+		{0, []string{"a.yaml", "base/b.yaml", "base/nested/c.yml", "base/nested/deep/d.yaml"}},
+		{1, []string{"a.yaml"}},
+		{2, []string{"a.yaml", "base/b.yaml"}},
+		{3, []string{"a.yaml", "base/b.yaml", "base/nested/c.yml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.maxDepth), func(t *testing.T) {
+			got, err := FindFilesWithRegex(&root, `\.ya?ml$`, tt.maxDepth)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range got {
+				got[i] = filepath.ToSlash(got[i])
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("FindFilesWithRegex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFilesWithRegexInvalidExpression(t *testing.T) {
+	root := t.TempDir()
+
+	if _, err := FindFilesWithRegex(&root, "(", 0); err == nil {
+		t.Error("FindFilesWithRegex() with invalid expression returned no error")
+	}
+}